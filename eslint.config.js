@@ -33,6 +33,7 @@ export default [
         clearTimeout: 'readonly',
         setInterval: 'readonly',
         clearInterval: 'readonly',
+        AbortController: 'readonly',
         NodeJS: 'readonly',
       },
     },
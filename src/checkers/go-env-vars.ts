@@ -0,0 +1,75 @@
+/**
+ * Undocumented Go environment variables
+ *
+ * When a project keeps a .env.example, every variable read with os.Getenv
+ * or os.LookupEnv should be listed in it; otherwise the next deployment
+ * finds out by running with an empty value.
+ */
+
+import { existsSync, readFileSync } from 'fs';
+import { join } from 'path';
+import type { DiagnosticResult } from '../types/DiagnosticResult';
+
+export const ENV_EXAMPLE_FILE = '.env.example';
+
+// The name argument is captured when it's a string literal
+const ENV_READ_PATTERN = /\bos\.(?:Getenv|LookupEnv)\(\s*("(?:[^"\\]|\\.)*"|`[^`]*`)?/g;
+
+/**
+ * Variable names listed in the project's .env.example (KEY=value lines,
+ * optionally with export), or null when it has none
+ */
+export function readEnvExample(projectRoot: string): Set<string> | null {
+  const path = join(projectRoot, ENV_EXAMPLE_FILE);
+  if (!existsSync(path)) {
+    return null;
+  }
+  const names = readFileSync(path, 'utf8')
+    .split('\n')
+    .map((line) => line.match(/^\s*(?:export\s+)?([A-Za-z_]\w*)\s*=/)?.[1])
+    .filter((name): name is string => !!name);
+  return new Set(names);
+}
+
+/**
+ * Info diagnostics for environment variables read in source that aren't
+ * documented, and for reads whose name is only known at runtime
+ */
+export function checkEnvVars(source: string, documented: Set<string>): DiagnosticResult[] {
+  const diagnostics: DiagnosticResult[] = [];
+
+  source.split('\n').forEach((line, index) => {
+    for (const call of line.matchAll(ENV_READ_PATTERN)) {
+      const column = (call.index ?? 0) + 1;
+      const literal = call[1];
+      const rest = line.slice((call.index ?? 0) + call[0].length).trimStart();
+      // Arguments continued on the next line can't be read from this one
+      if (!literal && !rest) {
+        continue;
+      }
+      // A literal followed by more of an expression (e.g. "APP_" + name) is dynamic too
+      if (!literal || !rest.startsWith(')')) {
+        diagnostics.push({
+          line: index + 1,
+          column,
+          severity: 'info',
+          message: 'environment variable name is dynamic, analysis skipped',
+          code: 'envvars',
+        });
+        continue;
+      }
+      const name = literal.slice(1, -1);
+      if (!documented.has(name)) {
+        diagnostics.push({
+          line: index + 1,
+          column,
+          severity: 'info',
+          message: `environment variable ${name} is not documented; add it to ${ENV_EXAMPLE_FILE}`,
+          code: 'envvars',
+        });
+      }
+    }
+  });
+
+  return diagnostics;
+}
@@ -0,0 +1,210 @@
+/**
+ * Go regular expression validation
+ *
+ * regexp.MustCompile panics and regexp.Compile returns an error at runtime
+ * when a pattern is invalid, but the compiler accepts any string. Constant
+ * patterns are checked against the RE2 syntax rules of Go's regexp/syntax
+ * parser, with its error messages. Patterns written for PCRE or JavaScript
+ * (lookaround, backreferences, possessive quantifiers) are the usual cause.
+ */
+
+import type { DiagnosticResult } from '../types/DiagnosticResult';
+
+const CALL_PATTERN =
+  /\bregexp\.(MustCompile|Compile|MustCompilePOSIX|CompilePOSIX|MatchString|Match)\(\s*(`[^`]*`|"(?:[^"\\]|\\.)*")/g;
+
+// Go's parser rejects larger repeat counts
+const MAX_REPEAT = 1000;
+
+const SIMPLE_ESCAPES: Record<string, string> = { '\\': '\\', '"': '"', n: '\n', r: '\r', t: '\t' };
+
+/**
+ * The value of a Go string literal, or null for escapes this doesn't decode
+ */
+function stringValue(literal: string): string | null {
+  if (literal.startsWith('`')) {
+    return literal.slice(1, -1);
+  }
+  let value = '';
+  const body = literal.slice(1, -1);
+  for (let i = 0; i < body.length; i++) {
+    if (body[i] !== '\\') {
+      value += body[i];
+      continue;
+    }
+    const escaped = SIMPLE_ESCAPES[body[i + 1] ?? ''];
+    if (escaped === undefined) {
+      return null;
+    }
+    value += escaped;
+    i++;
+  }
+  return value;
+}
+
+/**
+ * Why Go's regexp package rejects a pattern (the error after "error parsing
+ * regexp: ", with a hint for syntax RE2 leaves out), or null if it accepts it
+ */
+export function validateRegexp(pattern: string): string | null {
+  let depth = 0;
+  // Whether the last item can take a repetition operator, and the operator it already has
+  let repeatable = false;
+  let repetition: string | null = null;
+  let lazy = false;
+
+  for (let i = 0; i < pattern.length; i++) {
+    const char = pattern[i];
+    const rest = pattern.slice(i);
+
+    if (char === '\\') {
+      const next = pattern[i + 1];
+      if (next === undefined) {
+        return 'trailing backslash at end of expression: ``';
+      }
+      // \1 to \7 followed by more digits are octal escapes; a lone digit is a backreference
+      if (/[1-9]/.test(next) && !(/[1-7]/.test(next) && /[0-7]/.test(pattern[i + 2] ?? ''))) {
+        return `invalid escape sequence: \`\\${next}\` (RE2 has no backreferences)`;
+      }
+      if (next === 'Q') {
+        const end = pattern.indexOf('\\E', i + 2);
+        i = end === -1 ? pattern.length : end + 1;
+      } else if ('pPx'.includes(next) && pattern[i + 2] === '{') {
+        const end = pattern.indexOf('}', i + 3);
+        i = end === -1 ? pattern.length : end;
+      } else {
+        i++;
+      }
+      repeatable = true;
+      repetition = null;
+      continue;
+    }
+
+    if (char === '[') {
+      // A ] right after [ or [^ is a literal; [:alpha:] classes nest their own brackets
+      let end = i + 1;
+      if (pattern[end] === '^') end++;
+      if (pattern[end] === ']') end++;
+      while (end < pattern.length && pattern[end] !== ']') {
+        if (pattern[end] === '\\') {
+          end++;
+        } else if (pattern.startsWith('[:', end)) {
+          const close = pattern.indexOf(':]', end + 2);
+          if (close !== -1) end = close + 1;
+        }
+        end++;
+      }
+      if (end >= pattern.length) {
+        return `missing closing ]: \`${rest}\``;
+      }
+      i = end;
+      repeatable = true;
+      repetition = null;
+      continue;
+    }
+
+    if (char === '(') {
+      if (pattern[i + 1] === '?') {
+        const lookaround = rest.match(/^\(\?<?[=!]/)?.[0];
+        if (lookaround) {
+          return `invalid or unsupported Perl syntax: \`${lookaround}\` (RE2 has no lookaround)`;
+        }
+        // Flags like (?i) apply to the rest of the group and can't be repeated
+        const flags = rest.match(/^\(\?[imsU-]*\)/)?.[0];
+        if (flags) {
+          i += flags.length - 1;
+          repeatable = false;
+          repetition = null;
+          continue;
+        }
+        const group = rest.match(/^\(\?(?:P?<\w+>|[imsU-]*:)/)?.[0];
+        if (!group) {
+          return `invalid or unsupported Perl syntax: \`${rest.slice(0, 3)}\``;
+        }
+        i += group.length - 1;
+      }
+      depth++;
+      repeatable = false;
+      repetition = null;
+      continue;
+    }
+
+    if (char === ')') {
+      if (depth === 0) {
+        return `unexpected ): \`${pattern}\``;
+      }
+      depth--;
+      repeatable = true;
+      repetition = null;
+      continue;
+    }
+
+    if (char === '|') {
+      repeatable = false;
+      repetition = null;
+      continue;
+    }
+
+    const count = char === '{' ? rest.match(/^\{(\d+)(,(\d*))?\}/) : null;
+    if (char === '*' || char === '+' || char === '?' || count) {
+      const operator = count ? count[0] : (char ?? '');
+      // One ? right after a repetition makes it non-greedy
+      if (repetition !== null && operator === '?' && !lazy) {
+        repetition += operator;
+        lazy = true;
+        continue;
+      }
+      if (repetition !== null) {
+        return `invalid nested repetition operator: \`${repetition}${operator}\``;
+      }
+      if (!repeatable) {
+        return `missing argument to repetition operator: \`${operator}\``;
+      }
+      if (count) {
+        const min = parseInt(count[1] ?? '0', 10);
+        const max = count[3] ? parseInt(count[3], 10) : min;
+        if (min > MAX_REPEAT || max > MAX_REPEAT || (count[2] && count[3] && max < min)) {
+          return `invalid repeat count: \`${operator}\``;
+        }
+        i += operator.length - 1;
+      }
+      repetition = operator;
+      lazy = false;
+      continue;
+    }
+
+    repeatable = true;
+    repetition = null;
+  }
+
+  return depth > 0 ? `missing closing ): \`${pattern}\`` : null;
+}
+
+/**
+ * Errors for every constant pattern passed to a regexp function in source
+ * that Go's regexp package would reject
+ */
+export function checkRegexps(source: string): DiagnosticResult[] {
+  const diagnostics: DiagnosticResult[] = [];
+
+  source.split('\n').forEach((line, index) => {
+    if (line.trimStart().startsWith('//')) {
+      return;
+    }
+    for (const match of line.matchAll(CALL_PATTERN)) {
+      const pattern = stringValue(match[2] ?? '');
+      const problem = pattern === null ? null : validateRegexp(pattern);
+      if (!problem) continue;
+      const fails = match[1]?.startsWith('Must') ? 'panics' : 'returns an error';
+      diagnostics.push({
+        line: index + 1,
+        column: (match.index ?? 0) + 1,
+        severity: 'error',
+        message: `regexp.${match[1]} ${fails} at runtime: error parsing regexp: ${problem}`,
+        code: 'regexp',
+      });
+    }
+  });
+
+  return diagnostics;
+}
@@ -0,0 +1,97 @@
+/**
+ * Go HTTP route conflicts
+ *
+ * Registering a path twice doesn't fail to compile: net/http panics when the
+ * server starts and gorilla/mux silently routes to the first handler. With
+ * gorilla/mux a template registered earlier (/users/{id}) also takes the
+ * requests of a literal path registered after it (/users/create).
+ */
+
+import type { DiagnosticResult } from '../types/DiagnosticResult';
+
+interface Route {
+  path: string;
+  line: number;
+  column: number;
+  /** Methods from a chained .Methods(...); empty means any */
+  methods: string[];
+}
+
+function routeSegments(path: string): string[] {
+  return path.replace(/\/+$/, '').split('/');
+}
+
+/**
+ * Whether requests for the literal path would go to the template's handler
+ */
+function templateMatches(template: string, path: string): boolean {
+  const patterns = routeSegments(template);
+  const segments = routeSegments(path);
+  return (
+    patterns.length === segments.length &&
+    patterns.every((pattern, i) => /^\{[^}]+\}$/.test(pattern) || pattern === segments[i])
+  );
+}
+
+function sharesMethod(a: Route, b: Route): boolean {
+  return (
+    a.methods.length === 0 ||
+    b.methods.length === 0 ||
+    a.methods.some((method) => b.methods.includes(method))
+  );
+}
+
+/**
+ * Warnings for routes registered on the same router as an earlier route
+ * that already handles them
+ */
+export function checkRoutes(source: string): DiagnosticResult[] {
+  // Variables holding a gorilla/mux router, whose routes match in order
+  const gorillaRouters = new Set<string>();
+  const routes = new Map<string, Route[]>();
+  const diagnostics: DiagnosticResult[] = [];
+
+  source.split('\n').forEach((line, index) => {
+    const router = line.match(/\b(\w+)\s*:?=\s*(?:mux\.NewRouter\(\)|\w+\.PathPrefix\()/);
+    if (router?.[1]) {
+      gorillaRouters.add(router[1]);
+    }
+
+    const registration = line.match(/\b(\w+)\.(?:HandleFunc|Handle)\(\s*"([^"]*)"/);
+    if (!registration || !registration[1] || registration[2] === undefined) {
+      return;
+    }
+    const receiver = registration[1];
+    const path = registration[2];
+    const methodList = line.match(/\.Methods\(([^)]*)\)/)?.[1] ?? '';
+    const methods = [...methodList.matchAll(/"(\w+)"/g)].map((m) => (m[1] ?? '').toUpperCase());
+    const route = { path, line: index + 1, column: (registration.index ?? 0) + 1, methods };
+
+    const earlier = routes.get(receiver) ?? [];
+    const duplicate = earlier.find((other) => other.path === path && sharesMethod(other, route));
+    const shadowing = gorillaRouters.has(receiver)
+      ? earlier.find((other) => templateMatches(other.path, path) && sharesMethod(other, route))
+      : undefined;
+    if (duplicate) {
+      diagnostics.push({
+        line: route.line,
+        column: route.column,
+        severity: 'warning',
+        message: `route "${path}" is already registered on ${receiver} at line ${duplicate.line}`,
+        code: 'routes',
+      });
+    } else if (shadowing) {
+      const first = `"${shadowing.path}" at line ${shadowing.line}`;
+      diagnostics.push({
+        line: route.line,
+        column: route.column,
+        severity: 'warning',
+        message: `route "${path}" is unreachable: ${first} matches it first`,
+        code: 'routes',
+      });
+    }
+    routes.set(receiver, [...earlier, route]);
+  });
+
+  return diagnostics;
+}
@@ -0,0 +1,122 @@
+/**
+ * Go struct tag validation
+ *
+ * The compiler accepts any string as a struct tag, so `json:name` or
+ * `json:"id" json:"user_id"` only fail at runtime, when encoding/json
+ * silently ignores them. This applies the reflect.StructTag conventions
+ * (the rules go vet's structtag analyzer uses) to the fields of every
+ * struct in a file.
+ */
+
+import type { DiagnosticResult } from '../types/DiagnosticResult';
+
+function isKeyChar(charCode: number): boolean {
+  return charCode > 0x20 && charCode !== 0x7f && charCode !== 0x22 && charCode !== 0x3a;
+}
+
+/**
+ * The line without its // comment; // inside strings and tags (URLs) is kept
+ */
+function stripComment(line: string): string {
+  let quote: string | null = null;
+  for (let i = 0; i < line.length; i++) {
+    const char = line[i];
+    if (quote) {
+      if (char === '\\' && quote === '"') {
+        i++;
+      } else if (char === quote) {
+        quote = null;
+      }
+    } else if (char === '"' || char === '`') {
+      quote = char;
+    } else if (char === '/' && line[i + 1] === '/') {
+      return line.slice(0, i);
+    }
+  }
+  return line;
+}
+
+/**
+ * Why a tag doesn't follow the key:"value" convention, or null if it does
+ */
+export function validateStructTag(tag: string): string | null {
+  const keys = new Set<string>();
+  let rest = tag;
+  for (;;) {
+    rest = rest.replace(/^ +/, '');
+    if (!rest) {
+      return null;
+    }
+
+    // The key runs up to the first space, quote, colon or control character
+    let end = 0;
+    while (end < rest.length && isKeyChar(rest.charCodeAt(end))) {
+      end++;
+    }
+    const key = rest.slice(0, end);
+    if (!key) {
+      return 'bad syntax for struct tag key';
+    }
+    rest = rest.slice(key.length);
+    if (!rest.startsWith(':')) {
+      return 'bad syntax for struct tag pair';
+    }
+    const value = rest.slice(1).match(/^"(?:[^"\\]|\\.)*"/)?.[0];
+    if (!value) {
+      return 'bad syntax for struct tag value';
+    }
+    rest = rest.slice(1 + value.length);
+    if (rest && !rest.startsWith(' ')) {
+      return 'key:"value" pairs not separated by spaces';
+    }
+    if (keys.has(key)) {
+      return `repeated tag key "${key}"`;
+    }
+    keys.add(key);
+  }
+}
+
+/**
+ * Warnings for every malformed field tag inside a struct type in source
+ */
+export function checkStructTags(source: string): DiagnosticResult[] {
+  const diagnostics: DiagnosticResult[] = [];
+  // Brace depth at which each enclosing struct body was opened
+  const structDepths: number[] = [];
+  let depth = 0;
+
+  source.split('\n').forEach((line, index) => {
+    const code = stripComment(line);
+    const tag = code.match(/`([^`]*)`\s*$/);
+    if (structDepths.length > 0 && structDepths[structDepths.length - 1] === depth && tag) {
+      const problem = validateStructTag(tag[1] ?? '');
+      if (problem) {
+        diagnostics.push({
+          line: index + 1,
+          column: (tag.index ?? 0) + 1,
+          severity: 'warning',
+          message: `struct field tag \`${tag[1]}\` is not compatible with reflect.StructTag.Get: ${problem}`,
+          code: 'structtag',
+        });
+      }
+    }
+
+    // Tags are raw strings, so braces inside them don't count
+    const braces = code.replace(/`[^`]*`|"(?:[^"\\]|\\.)*"/g, '');
+    for (const match of braces.matchAll(/\bstruct\s*\{|[{}]/g)) {
+      if (match[0] === '}') {
+        if (structDepths[structDepths.length - 1] === depth) {
+          structDepths.pop();
+        }
+        depth--;
+      } else {
+        depth++;
+        if (match[0] !== '{') {
+          structDepths.push(depth);
+        }
+      }
+    }
+  });
+
+  return diagnostics;
+}
@@ -2,10 +2,56 @@
  * Go Language Checker Configuration
  */
 
-import { existsSync } from 'fs';
-import { join } from 'path';
+import { existsSync, readFileSync } from 'fs';
+import { basename, dirname, join } from 'path';
+import type { CheckerOptions } from '../file-checker';
 import type { LanguageConfig } from '../language-checker-registry';
 import type { DiagnosticResult } from '../types/DiagnosticResult';
+import { checkEnvVars, readEnvExample } from './go-env-vars';
+import { checkRegexps } from './go-regexps';
+import { checkRoutes } from './go-routes';
+import { checkStructTags } from './go-struct-tags';
+import { logger } from '../utils/logger';
+
+// Files left out by //go:build lines only type-check with the right tags
+const BUILD_CONSTRAINT_PATTERN = /\/\/go:build|\/\/ \+build|build constraints exclude/;
+const BUILD_TAGS_HINT = 'Consider re-running with the appropriate --build-tags.';
+
+// cgo compiles C headers and sources whose line numbers mean nothing in the Go file
+const C_SOURCE_PATTERN = /\.(c|h|cc|cpp|hpp)$/;
+
+export const GO_MOD_MODES = ['vendor', 'mod', 'readonly'] as const;
+export type GoModMode = (typeof GO_MOD_MODES)[number];
+
+/**
+ * The -mod mode for a project: the --mod override, else vendor when
+ * vendor/modules.txt exists so checks never download modules
+ */
+export function detectModMode(projectRoot: string, options: CheckerOptions = {}): GoModMode | null {
+  if (options.goMod) {
+    return options.goMod;
+  }
+  return existsSync(join(projectRoot, 'vendor', 'modules.txt')) ? 'vendor' : null;
+}
+
+/**
+ * GOFLAGS for a check, added to any the user already has
+ */
+export function goFlagsEnv(
+  projectRoot: string,
+  options: CheckerOptions = {}
+): Record<string, string> | undefined {
+  const flags = options.buildTags?.length ? [`-tags=${options.buildTags.join(',')}`] : [];
+  const modMode = detectModMode(projectRoot, options);
+  if (modMode) {
+    logger.info('go module mode', { phase: 'setup', mode: modMode, override: !!options.goMod });
+    flags.push(`-mod=${modMode}`);
+  }
+  if (flags.length === 0) {
+    return undefined;
+  }
+  return { GOFLAGS: [process.env.GOFLAGS, ...flags].filter(Boolean).join(' ') };
+}
 
 export const goConfig: LanguageConfig = {
   name: 'Go',
@@ -18,6 +64,10 @@ export const goConfig: LanguageConfig = {
   },
 
   buildArgs: (file: string, _projectRoot: string, _toolCommand: string, _context?: any) => {
+    // Test files need the test build of their whole package, which go build never makes
+    if (file.endsWith('_test.go')) {
+      return ['vet', dirname(file)];
+    }
     // Use go build for better error detection than go vet
     return ['build', file];
   },
@@ -25,26 +75,70 @@ export const goConfig: LanguageConfig = {
   parseOutput: (stdout: string, stderr: string, _file: string, _projectRoot: string) => {
     const diagnostics: DiagnosticResult[] = [];
     const lines = stderr.split('\n');
+    // go vet reports the whole package; keep the test file's own diagnostics, matching the
+    // whole file name so checking foo_test.go doesn't also keep barfoo_test.go's
+    const name = basename(_file).replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+    const onlyFile = _file.endsWith('_test.go') ? new RegExp(`(^|[/\\s])${name}:\\d+:`) : null;
 
     for (const line of lines) {
-      const match = line.match(/^.+?:(\d+):(\d+): (.+)$/);
-      if (match && match[1] && match[2] && match[3]) {
+      // Reported without a position when the file itself is excluded
+      if (line.includes('build constraints exclude all Go files')) {
         diagnostics.push({
-          line: parseInt(match[1]),
-          column: parseInt(match[2]),
+          line: 1,
+          column: 1,
           severity: 'error' as const,
-          message: match[3],
+          message: `${line.trim()}. ${BUILD_TAGS_HINT}`,
         });
+        continue;
+      }
+      if (onlyFile && !onlyFile.test(line)) {
+        continue;
+      }
+      const match = line.match(/^(.+?):(\d+):(\d+): (.+)$/);
+      if (match && match[1] && match[2] && match[3] && match[4]) {
+        const message = BUILD_CONSTRAINT_PATTERN.test(match[4])
+          ? `${match[4]}. ${BUILD_TAGS_HINT}`
+          : match[4];
+        // Keep the C position in the message and point at the top of the Go file
+        if (C_SOURCE_PATTERN.test(match[1])) {
+          diagnostics.push({
+            line: 1,
+            column: 1,
+            severity: 'error' as const,
+            message: `cgo: ${match[1]}:${match[2]}: ${message}`,
+          });
+          continue;
+        }
+        diagnostics.push({
+          line: parseInt(match[2]),
+          column: parseInt(match[3]),
+          severity: 'error' as const,
+          message,
+        });
+      }
+    }
+
+    // Malformed struct tags, invalid regexp patterns and conflicting routes compile fine, so
+    // go build never mentions them; go vet's structtag analyzer already reports a test file's tags
+    if (existsSync(_file)) {
+      const source = readFileSync(_file, 'utf8');
+      if (!onlyFile) {
+        diagnostics.push(...checkStructTags(source));
+      }
+      diagnostics.push(...checkRegexps(source), ...checkRoutes(source));
+      const documentedEnv = readEnvExample(_projectRoot);
+      if (documentedEnv) {
+        diagnostics.push(...checkEnvVars(source, documentedEnv));
       }
     }
 
     return diagnostics;
   },
 
-  setupCommand: async (_file: string, _projectRoot: string) => {
+  setupCommand: async (_file: string, _projectRoot: string, _options?: CheckerOptions) => {
     const hasGoMod = existsSync(join(_projectRoot, 'go.mod'));
     return {
-      context: { hasGoMod },
+      context: { hasGoMod, env: goFlagsEnv(_projectRoot, _options) },
     };
   },
 };
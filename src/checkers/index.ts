@@ -30,5 +30,7 @@ registerLanguage(luaConfig.extensions, luaConfig);
 registerLanguage(elixirConfig.extensions, elixirConfig);
 registerLanguage(terraformConfig.extensions, terraformConfig);
 
+// Plugins are loaded by the generic checker when it meets an extension none of these handle
+
 // Export registry for use in file-checker
 export { LANGUAGE_REGISTRY } from '../language-checker-registry';
@@ -0,0 +1,208 @@
+/**
+ * Plugin Checkers - third-party checkers as executables
+ *
+ * Every executable in ~/.config/claude-lsp/plugins/ (or $CLAUDE_LSP_PLUGIN_DIR)
+ * is a checker that can be written in any language. It is asked what it
+ * checks the first time a run meets a file no built-in checker handles, and
+ * then called per file. Each call is one JSON request on stdin, answered
+ * with JSON on stdout:
+ *
+ *   {"method": "describe"}
+ *     {"name": "Solidity", "extensions": [".sol"], "codes": {"7576": "undeclared-identifier"}}
+ *   {"method": "check", "file": "<file>", "projectRoot": "<projectRoot>"}
+ *     {"diagnostics": [{"line": 3, "column": 5, "severity": "error", "message": "...",
+ *                       "code": "7576"}]}
+ *
+ * The optional codes map renames the plugin's diagnostic codes (e.g. the
+ * underlying tool's numbers) for suppressions and --exclude-code.
+ * Plugins can't replace built-in checkers: extensions already registered are ignored.
+ */
+
+import { accessSync, constants, readdirSync, statSync } from 'fs';
+import { basename, extname, join } from 'path';
+import { homedir } from 'os';
+import {
+  LANGUAGE_REGISTRY,
+  mapSeverity,
+  registerLanguage,
+  type LanguageConfig,
+} from '../language-checker-registry';
+import type { DiagnosticResult } from '../types/DiagnosticResult';
+import { logger } from '../utils/logger';
+// Built-in checkers register first, so plugins only get the extensions they leave
+import './index';
+
+// describe runs in the middle of a check, so a hanging plugin must not stall it
+const DESCRIBE_TIMEOUT_MS = 2000;
+
+export interface PluginDescription {
+  name: string;
+  extensions: string[];
+  /** Diagnostic code -> the code to report instead */
+  codes?: Record<string, string>;
+}
+
+export function pluginDir(): string {
+  return process.env.CLAUDE_LSP_PLUGIN_DIR || join(homedir(), '.config', 'claude-lsp', 'plugins');
+}
+
+function pluginRequest(method: string, params: Record<string, string> = {}): string {
+  return JSON.stringify({ method, ...params }) + '\n';
+}
+
+function isExecutableFile(path: string): boolean {
+  try {
+    accessSync(path, constants.X_OK);
+    return statSync(path).isFile();
+  } catch {
+    return false;
+  }
+}
+
+/**
+ * Validate a plugin's describe answer
+ */
+export function parsePluginDescription(stdout: string): PluginDescription | null {
+  try {
+    const { name, extensions, codes } = JSON.parse(stdout) as Record<string, unknown>;
+    if (
+      typeof name !== 'string' ||
+      !name ||
+      !Array.isArray(extensions) ||
+      !extensions.every((ext) => typeof ext === 'string' && ext.startsWith('.'))
+    ) {
+      return null;
+    }
+    const description: PluginDescription = { name, extensions: extensions as string[] };
+    if (codes && typeof codes === 'object' && !Array.isArray(codes)) {
+      description.codes = Object.fromEntries(
+        Object.entries(codes).filter(
+          (entry): entry is [string, string] => typeof entry[1] === 'string'
+        )
+      );
+    }
+    return description;
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * Read a plugin's check answer; output that isn't the expected JSON has no diagnostics
+ */
+export function parsePluginOutput(
+  stdout: string,
+  codes: Record<string, string> = {}
+): DiagnosticResult[] {
+  let parsed: unknown;
+  try {
+    parsed = JSON.parse(stdout);
+  } catch {
+    return [];
+  }
+  const diagnostics = (parsed as { diagnostics?: unknown })?.diagnostics;
+  if (!Array.isArray(diagnostics)) {
+    return [];
+  }
+
+  return diagnostics.flatMap((raw: Record<string, unknown>) => {
+    if (typeof raw?.line !== 'number' || typeof raw.message !== 'string') {
+      return [];
+    }
+    const diagnostic: DiagnosticResult = {
+      line: raw.line,
+      column: typeof raw.column === 'number' ? raw.column : 1,
+      severity: mapSeverity(typeof raw.severity === 'string' ? raw.severity : 'error'),
+      message: raw.message,
+    };
+    if (typeof raw.code === 'string') {
+      diagnostic.code = codes[raw.code] ?? raw.code;
+    }
+    return [diagnostic];
+  });
+}
+
+export function pluginConfig(path: string, description: PluginDescription): LanguageConfig {
+  return {
+    name: description.name,
+    tool: basename(path),
+    extensions: description.extensions,
+    // Absolute, so the plugin is always run from the plugin directory
+    localPaths: [path],
+
+    buildArgs: (file: string, projectRoot: string) => ({
+      args: [],
+      stdin: pluginRequest('check', { file, projectRoot }),
+    }),
+
+    parseOutput: (stdout: string) => parsePluginOutput(stdout, description.codes),
+  };
+}
+
+/**
+ * Register every plugin in dir for the extensions no checker handles yet.
+ * Returns the names of the plugins that were registered.
+ */
+export function loadPlugins(dir: string = pluginDir()): string[] {
+  let entries: string[];
+  try {
+    entries = readdirSync(dir).sort();
+  } catch {
+    return [];
+  }
+
+  const loaded: string[] = [];
+  for (const entry of entries) {
+    const path = join(dir, entry);
+    if (!isExecutableFile(path)) {
+      continue;
+    }
+    const proc = Bun.spawnSync([path], {
+      stdin: Buffer.from(pluginRequest('describe')),
+      timeout: DESCRIBE_TIMEOUT_MS,
+    });
+    const description = proc.exitCode === 0 ? parsePluginDescription(proc.stdout.toString()) : null;
+    if (!description) {
+      logger.warn('plugin ignored: describe failed', { phase: 'setup', plugin: path });
+      continue;
+    }
+
+    const extensions = description.extensions.filter(
+      (ext) => !LANGUAGE_REGISTRY.has(ext.toLowerCase())
+    );
+    if (extensions.length === 0) {
+      logger.warn('plugin ignored: extensions already checked', { phase: 'setup', plugin: path });
+      continue;
+    }
+    registerLanguage(extensions, pluginConfig(path, { ...description, extensions }));
+    loaded.push(description.name);
+  }
+  return loaded;
+}
+
+let pluginsLoaded = false;
+
+/**
+ * Load the plugins in dir on the first call only, so runs that never check
+ * a file without a built-in checker don't spawn any describe calls
+ */
+export function ensurePluginsLoaded(dir: string = pluginDir()): void {
+  if (pluginsLoaded) {
+    return;
+  }
+  pluginsLoaded = true;
+  loadPlugins(dir);
+}
+
+/**
+ * Whether a built-in or plugin checker handles this file's extension,
+ * loading the plugins on the first call
+ */
+export function hasRegisteredChecker(filePath: string): boolean {
+  const ext = extname(filePath).toLowerCase();
+  if (!ext) {
+    return false;
+  }
+  ensurePluginsLoaded();
+  return LANGUAGE_REGISTRY.has(ext);
+}
@@ -61,6 +61,7 @@ export const pythonConfig: LanguageConfig = {
       column: number;
       severity: 'error' | 'warning' | 'info';
       message: string;
+      code?: string;
     }> = [];
 
     if (!stdout.trim() && !stderr.trim()) return diagnostics;
@@ -83,6 +84,8 @@ export const pythonConfig: LanguageConfig = {
             column: diag.range?.start?.character ? diag.range.start.character + 1 : 1,
             severity: mapSeverity(diag.severity || 'error'),
             message: message.trim(),
+            // Pyright names the rule that produced the diagnostic (e.g. reportMissingImports)
+            ...(diag.rule ? { code: diag.rule } : {}),
           });
         }
       }
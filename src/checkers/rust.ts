@@ -10,6 +10,22 @@ import type { LanguageConfig } from '../language-checker-registry';
 import { mapSeverity } from '../language-checker-registry';
 import type { DiagnosticResult } from '../types/DiagnosticResult';
 
+/**
+ * Build a diagnostic message from a rustc JSON message, appending its help and
+ * note children so rustc's suggestions travel with the one-line message
+ */
+function describeRustMessage(message: any): string {
+  const parts: string[] = [message.message || 'Unknown error'];
+
+  for (const child of message.children || []) {
+    if ((child.level === 'help' || child.level === 'note') && child.message) {
+      parts.push(`${child.level}: ${child.message}`);
+    }
+  }
+
+  return parts.join('; ');
+}
+
 export const rustConfig: LanguageConfig = {
   name: 'Rust',
   tool: 'rustc', // Default to rustc, will be overridden in buildArgs if cargo project
@@ -75,7 +91,8 @@ export const rustConfig: LanguageConfig = {
                 line: span.line_start || 1,
                 column: span.column_start || 1,
                 severity,
-                message: parsed.message.message || 'Unknown error',
+                message: describeRustMessage(parsed.message),
+                ...(parsed.message.code?.code ? { code: parsed.message.code.code } : {}),
               });
             }
           }
@@ -91,20 +108,22 @@ export const rustConfig: LanguageConfig = {
                 line: span.line_start || 1,
                 column: span.column_start || 1,
                 severity,
-                message: parsed.message || 'Unknown error',
+                message: describeRustMessage(parsed),
+                ...(parsed.code?.code ? { code: parsed.code.code } : {}),
               });
             }
           }
         }
       } catch (e) {
         // Not JSON, try to parse as plain text error
-        const match = line.match(/error(?:\[E\d+\])?: (.+)/);
-        if (match) {
+        const match = line.match(/error(?:\[(E\d+)\])?: (.+)/);
+        if (match && match[2]) {
           diagnostics.push({
             line: 1,
             column: 1,
             severity: 'error' as const,
-            message: match[1],
+            message: match[2],
+            ...(match[1] ? { code: match[1] } : {}),
           });
         }
       }
@@ -3,19 +3,22 @@
  */
 
 import { existsSync } from 'fs';
-import { join, resolve } from 'path';
+import { extname, join, resolve } from 'path';
 import { tmpdir } from 'os';
 import { createHash } from 'crypto';
 import type { LanguageConfig } from '../language-checker-registry';
 import { mapSeverity, stripAnsiCodes, shouldSkipDiagnostic } from '../language-checker-registry';
-import { findTsconfigRoot } from '../utils/common';
+import { findTsconfigRoot, isGitignored } from '../utils/common';
 
 // findTsconfigRoot is now imported from utils/common
 
+// JavaScript extensions are checked by tsc via allowJs/checkJs
+const JS_EXTENSIONS = ['.js', '.jsx', '.mjs', '.cjs'];
+
 export const typescriptConfig: LanguageConfig = {
   name: 'TypeScript',
   tool: 'tsc',
-  extensions: ['.ts', '.tsx', '.mts', '.cts'],
+  extensions: ['.ts', '.tsx', '.mts', '.cts', '.js', '.jsx', '.mjs', '.cjs'],
   localPaths: ['node_modules/.bin/tsc'],
 
   buildArgs: (file: string, projectRoot: string, _toolCommand: string, context?: any) => {
@@ -44,6 +47,12 @@ export const typescriptConfig: LanguageConfig = {
   setupCommand: async (file: string, projectRoot: string) => {
     const tsconfigRoot = findTsconfigRoot(file);
 
+    // checkJs would otherwise type-check gitignored build output (dist/, bundles)
+    const isJavaScript = JS_EXTENSIONS.includes(extname(file).toLowerCase());
+    if (isJavaScript && isGitignored(resolve(file))) {
+      return { context: { skipChecking: true } };
+    }
+
     // Create temp tsconfig for single-file checking
     // Include file path in hash to avoid conflicts when checking multiple files in parallel
     const projectHash = createHash('md5').update(projectRoot).digest('hex');
@@ -136,6 +145,15 @@ export const typescriptConfig: LanguageConfig = {
       compilerOptions.baseUrl = projectRoot;
     }
 
+    // JavaScript files need allowJs to be included at all; checkJs defaults to on
+    // but a project that explicitly sets checkJs: false keeps that choice
+    if (isJavaScript) {
+      compilerOptions.allowJs = true;
+      if (compilerOptions.checkJs === undefined) {
+        compilerOptions.checkJs = true;
+      }
+    }
+
     // Always enforce these settings
     compilerOptions.noEmit = true;
     compilerOptions.skipLibCheck = true;
@@ -159,6 +177,7 @@ export const typescriptConfig: LanguageConfig = {
       column: number;
       severity: 'error' | 'warning' | 'info';
       message: string;
+      code?: string;
     }> = [];
 
     // TypeScript outputs to stderr when there are errors
@@ -174,14 +193,14 @@ export const typescriptConfig: LanguageConfig = {
       if (!stripped) continue;
 
       // Match TypeScript diagnostic format
-      const match = stripped.match(/^(.+?)\((\d+),(\d+)\):\s+(error|warning)\s+TS\d+:\s*(.+)$/);
+      const match = stripped.match(/^(.+?)\((\d+),(\d+)\):\s+(error|warning)\s+(TS\d+):\s*(.+)$/);
       if (!match) continue;
 
-      const [, filePath, lineStr, colStr, severity, message] = match;
+      const [, filePath, lineStr, colStr, severity, code, message] = match;
 
       // With noUncheckedIndexedAccess, all array access could be undefined
       // Check that we have all required values from the regex match
-      if (!filePath || !lineStr || !colStr || !severity || !message) {
+      if (!filePath || !lineStr || !colStr || !severity || !code || !message) {
         continue;
       }
 
@@ -208,6 +227,7 @@ export const typescriptConfig: LanguageConfig = {
         column: colNum,
         severity: mapSeverity(severity),
         message: message.trim(),
+        code,
       });
     }
 
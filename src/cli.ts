@@ -8,20 +8,62 @@
  *
  * Commands:
  *   hook <event-type>     - Handle Claude Code hook events
- *   check <file>          - Check file for errors
+ *   check <file> [opts]   - Check file for errors (see help for options)
  *   disable <language>    - Disable language checking
  *   enable <language>     - Enable language checking
+ *   config init           - Write common settings to the config file
+ *   completion <shell>    - Print a shell completion script
+ *   health                - Diagnose installation problems
+ *   version               - Print version and build metadata
+ *   benchmark <file>      - Measure checking latency per phase
+ *   serve                 - Check files over HTTP for editor extensions
+ *   mcp                   - Serve the analyze_file tool over MCP stdio
+ *   history <subcommand>  - Browse runs recorded with check --history (list|show|trend)
  *   help                  - Show help
  */
 
 import {
   runCheck,
   runCheckMultiple,
+  runCheckStdin,
+  runWatch,
+  completionScript,
+  COMPLETION_SHELLS,
   enableLanguage,
   disableLanguage,
   showHelp,
+  runHealth,
+  runVersion,
+  runConfigInit,
+  parseBenchmarkArgs,
+  runBenchmark,
+  formatBenchmark,
+  parseServeArgs,
+  runServe,
+  runMcp,
+  parseHistoryArgs,
+  runHistory,
 } from './cli/commands';
 import { handlePostToolUse } from './cli/hooks/post-tool-use';
+import { existsSync } from 'fs';
+import { dirname, resolve } from 'path';
+import { createInterface, type Interface } from 'readline/promises';
+import { parseCheckArgs, type CheckOptions } from './cli/utils/check-options';
+import { loadCheckConfig } from './cli/utils/check-config';
+import { withCheckHooks, type ReportListener } from './cli/utils/check-hooks';
+import { expandCheckPaths, readManifest } from './cli/utils/check-paths';
+import { ciDefaults, detectCIEnvironment } from './cli/utils/ci-environment';
+import { DiagnosticStore } from './cli/utils/history';
+import {
+  collectChangedFiles,
+  collectStagedFiles,
+  limitToPaths,
+  resolveRef,
+} from './cli/utils/git-changes';
+import { withProfiling } from './cli/utils/profiler';
+import { findUnusableToolPath } from './cli/utils/tool-paths';
+import { configureLogger } from './utils/logger';
+import { installSignalHandlers } from './utils/signals';
 
 // Parse command line arguments
 const rawArgs = Bun.argv.slice(2);
@@ -59,20 +101,166 @@ void (async () => {
       console.error('Event type required for hook command');
       process.exit(1);
     }
+    installSignalHandlers();
     await handleHookEvent(eventType);
   } else if (command === 'check') {
-    // Support checking multiple files for better performance
-    let hasErrors = false;
-    if (commandArgs.length > 1) {
-      hasErrors = await runCheckMultiple(commandArgs);
-    } else {
-      const file = commandArgs[0];
-      if (!file) {
-        // Exit silently when file argument is missing (for compatibility with tests)
+    installSignalHandlers();
+    const { files: argPaths, options: flagOptions, error } = parseCheckArgs(commandArgs);
+    if (error) {
+      console.error(error);
+      process.exit(1);
+    }
+
+    // Manifest entries are checked alongside any explicit paths
+    let paths = argPaths;
+    if (flagOptions.manifest) {
+      if (!existsSync(flagOptions.manifest)) {
+        console.error(`Manifest not found: ${flagOptions.manifest}`);
+        process.exit(1);
+      }
+      const argAbsolutePaths = new Set(argPaths.map((path) => resolve(path)));
+      paths = [
+        ...readManifest(flagOptions.manifest).filter((path) => !argAbsolutePaths.has(path)),
+        ...argPaths,
+      ];
+    }
+
+    // --config applies to everything that reads config during this run
+    if (flagOptions.config) {
+      process.env.CLAUDE_LSP_CONFIG = resolve(flagOptions.config);
+    }
+
+    // --ci defaults sit below config files and flags; --ci alone covers unknown CI systems
+    const defaults: CheckOptions = flagOptions.ci
+      ? ciDefaults(detectCIEnvironment() ?? 'generic')
+      : {};
+    configureLogger({
+      level: flagOptions.logLevel ?? defaults.logLevel,
+      file: flagOptions.logFile,
+      format: flagOptions.logFormat,
+    });
+
+    // Command line flags always win over config file values
+    const configOptions = loadCheckConfig();
+    const options = { ...defaults, ...configOptions, ...flagOptions };
+    if (options.outputFile) {
+      const outputDir = dirname(resolve(options.outputFile));
+      if (!existsSync(outputDir)) {
+        console.error(`--output-file: directory ${outputDir} does not exist`);
+        process.exit(1);
+      }
+    } else if (options.appendOutput) {
+      console.error('--append-output requires --output-file');
+      process.exit(1);
+    }
+
+    // NO_COLOR is what everything that writes escape codes checks, checker tools included
+    if (options.color === false) {
+      process.env.NO_COLOR = '1';
+    }
+
+    // The spinner would overwrite --verbose trace lines
+    if (options.quiet || options.verbose) {
+      options.progress = false;
+    }
+    // Exclude patterns and codes from config and flags add up rather than replace each other
+    if (configOptions.exclude && flagOptions.exclude) {
+      options.exclude = [...configOptions.exclude, ...flagOptions.exclude];
+    }
+    if (configOptions.excludeCodes && flagOptions.excludeCodes) {
+      options.excludeCodes = [...configOptions.excludeCodes, ...flagOptions.excludeCodes];
+    }
+    // Tool paths merge per tool, with flags winning
+    if (configOptions.toolPaths && flagOptions.toolPaths) {
+      options.toolPaths = { ...configOptions.toolPaths, ...flagOptions.toolPaths };
+    }
+    const toolPathError = options.toolPaths && findUnusableToolPath(options.toolPaths);
+    if (toolPathError) {
+      console.error(toolPathError);
+      process.exit(1);
+    }
+    // Annotate pull requests by default when running in GitHub Actions
+    if (!options.format && process.env.GITHUB_ACTIONS === 'true') {
+      options.format = 'github';
+    }
+    let files = expandCheckPaths(paths);
+
+    if (options.sinceCommit !== undefined) {
+      const ref = options.sinceCommit || process.env.GITHUB_BASE_REF;
+      if (!ref) {
+        console.error('--since-commit requires a git ref when GITHUB_BASE_REF is not set');
+        process.exit(1);
+      }
+      const changed = await collectChangedFiles(ref);
+      if (changed.error) {
+        console.error(changed.error);
+        process.exit(1);
+      }
+      files = limitToPaths(changed.files, paths);
+      if (files.length === 0) {
+        console.error(`No supported files changed since ${ref}`);
+        process.exit(0);
+      }
+    }
+
+    if (options.blameSince) {
+      const base = await resolveRef(options.blameSince);
+      if (!base) {
+        console.error(`--blame-since: unknown git ref "${options.blameSince}"`);
         process.exit(1);
       }
-      hasErrors = await runCheck(file);
+      options.blameSince = base;
+    } else if (options.author) {
+      console.error('--author requires --blame-since');
+      process.exit(1);
+    }
+
+    if (options.preCommit) {
+      if (options.sinceCommit !== undefined) {
+        console.error('--pre-commit cannot be combined with --since-commit');
+        process.exit(1);
+      }
+      const staged = await collectStagedFiles();
+      if (staged.error) {
+        console.error(staged.error);
+        process.exit(1);
+      }
+      files = limitToPaths(staged.files, paths);
+      if (files.length === 0) {
+        console.error('No supported files staged');
+        process.exit(0);
+      }
+    }
+
+    if (options.stdin) {
+      if (!options.language) {
+        console.error('--stdin requires --language (e.g. --stdin --language go)');
+        process.exit(1);
+      }
+      const hasErrors = await runCheckStdin(await Bun.stdin.text(), options.language, options);
+      process.exit(hasErrors ? 1 : 0);
+    }
+
+    if (options.watch) {
+      await runWatch(files, options);
+      process.exit(0);
+    }
+
+    const file = files[0];
+    if (!file) {
+      // Exit silently when file argument is missing (for compatibility with tests)
+      process.exit(1);
     }
+    // Support checking multiple files for better performance
+    const check = (onReport: ReportListener): Promise<boolean> =>
+      files.length > 1
+        ? runCheckMultiple(files, { ...options, onReport })
+        : runCheck(file, { ...options, onReport });
+    const checkWithHooks = (): Promise<boolean> =>
+      withCheckHooks(options.hooks, files.length, check);
+    const hasErrors = options.profile
+      ? await withProfiling(options.profile, checkWithHooks)
+      : await checkWithHooks();
     // Exit with code 1 if errors were found
     if (hasErrors) {
       process.exit(1);
@@ -93,6 +281,89 @@ void (async () => {
     }
     const result = await enableLanguage(language);
     console.log(result);
+  } else if (command === 'config') {
+    const [subcommand, ...flags] = commandArgs;
+    if (
+      subcommand !== 'init' ||
+      flags.some((flag) => flag !== '--non-interactive' && flag !== '--project')
+    ) {
+      console.error('Usage: claude-lsp-cli config init [--non-interactive] [--project]');
+      process.exit(1);
+    }
+    // Only open stdin when there is something to ask
+    let readline: Interface | undefined;
+    const configPath = await runConfigInit(
+      { interactive: !flags.includes('--non-interactive'), project: flags.includes('--project') },
+      (question) =>
+        (readline ??= createInterface({ input: process.stdin, output: process.stdout })).question(
+          question
+        )
+    );
+    readline?.close();
+    console.log(`Wrote ${configPath}`);
+  } else if (command === 'health') {
+    const healthy = await runHealth();
+    process.exit(healthy ? 0 : 1);
+  } else if (command === 'version' || command === '--version') {
+    // Accepts --format json and --format=json
+    const format = commandArgs.join('=').replace(/^--format=/, '') || 'text';
+    if (format !== 'text' && format !== 'json') {
+      console.error('Usage: claude-lsp-cli version [--format text|json]');
+      process.exit(1);
+    }
+    await runVersion(format);
+  } else if (command === 'benchmark') {
+    const { file, options, error } = parseBenchmarkArgs(commandArgs);
+    if (error || !file) {
+      console.error(error ?? 'Error: benchmark requires a file');
+      console.error(
+        'Usage: claude-lsp-cli benchmark <file> [--iterations N] [--warmup N] [--format text|json]'
+      );
+      process.exit(1);
+    }
+    const result = await runBenchmark(file, options);
+    if (!result) {
+      console.error(`Cannot benchmark ${file}: file not found or language not supported`);
+      process.exit(1);
+    }
+    console.log(
+      options.format === 'json' ? JSON.stringify(result, null, 2) : formatBenchmark(result)
+    );
+  } else if (command === 'serve') {
+    const { options, error } = parseServeArgs(commandArgs);
+    if (error) {
+      console.error(error);
+      console.error('Usage: claude-lsp-cli serve [--port N] [--host H] [--server-token T]');
+      process.exit(1);
+    }
+    await runServe(options);
+  } else if (command === 'mcp') {
+    await runMcp();
+  } else if (command === 'history') {
+    const { args, error } = parseHistoryArgs(commandArgs);
+    if (error || !args) {
+      console.error(error);
+      console.error('Usage: claude-lsp-cli history list [--since 7d] [--file path]');
+      console.error('       claude-lsp-cli history show <run ID>');
+      console.error('       claude-lsp-cli history trend [--since 30d] [--group-by code]');
+      process.exit(1);
+    }
+    const store = new DiagnosticStore();
+    const { output, error: historyError } = runHistory(args, store);
+    store.close();
+    if (historyError) {
+      console.error(historyError);
+      process.exit(1);
+    }
+    console.log(output);
+  } else if (command === 'completion') {
+    const shell = commandArgs[0] ?? '';
+    const script = completionScript(shell);
+    if (!script) {
+      console.error(`Usage: claude-lsp-cli completion <${COMPLETION_SHELLS.join('|')}>`);
+      process.exit(1);
+    }
+    process.stdout.write(script);
   } else if (command === 'help') {
     await showHelp();
   } else {
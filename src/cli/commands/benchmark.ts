@@ -0,0 +1,140 @@
+/**
+ * Benchmark command - measure checking latency
+ *
+ * `claude-lsp-cli benchmark <file>` checks a file repeatedly and reports
+ * p50/p95/p99 durations per checker phase. Warmup runs are left out so
+ * tool caches (tsc build info, the Go build cache, ...) are hot.
+ */
+
+import { existsSync } from 'fs';
+import { relative, resolve } from 'path';
+import { performance } from 'perf_hooks';
+import { checkFile, type CheckerPhase } from '../../file-checker';
+
+export type BenchmarkPhase = CheckerPhase | 'total';
+
+const PHASES: BenchmarkPhase[] = ['setup', 'run', 'parse', 'total'];
+const PERCENTILES = [50, 95, 99];
+
+export interface BenchmarkOptions {
+  iterations: number;
+  warmup: number;
+  format: 'text' | 'json';
+}
+
+export interface BenchmarkResult {
+  file: string;
+  tool: string;
+  iterations: number;
+  /** Milliseconds per phase, then per percentile, e.g. phases.run.p95 */
+  phases: Record<BenchmarkPhase, Record<string, number>>;
+}
+
+export interface ParsedBenchmarkArgs {
+  file?: string;
+  options: BenchmarkOptions;
+  error?: string;
+}
+
+export function parseBenchmarkArgs(args: string[]): ParsedBenchmarkArgs {
+  const options: BenchmarkOptions = { iterations: 10, warmup: 1, format: 'text' };
+  let file: string | undefined;
+
+  for (let i = 0; i < args.length; i++) {
+    const arg = args[i] ?? '';
+    if (!arg.startsWith('--')) {
+      file = arg;
+      continue;
+    }
+    const [flag = '', inline] = arg.split('=', 2);
+    const value = inline ?? args[++i];
+    if (flag === '--format' && (value === 'text' || value === 'json')) {
+      options.format = value;
+    } else if ((flag === '--iterations' || flag === '--warmup') && value && /^\d+$/.test(value)) {
+      options[flag === '--iterations' ? 'iterations' : 'warmup'] = parseInt(value, 10);
+    } else {
+      return { file, options, error: `Invalid value for ${flag}: ${value ?? '(missing)'}` };
+    }
+  }
+
+  if (options.iterations < 1) {
+    return { file, options, error: '--iterations must be at least 1' };
+  }
+  return { file, options };
+}
+
+/**
+ * Nearest-rank percentile of the values
+ */
+export function percentile(values: number[], p: number): number {
+  if (values.length === 0) {
+    return 0;
+  }
+  const sorted = [...values].sort((a, b) => a - b);
+  const index = Math.min(sorted.length - 1, Math.max(0, Math.ceil((p / 100) * sorted.length) - 1));
+  return sorted[index] ?? 0;
+}
+
+/**
+ * Check the file warmup + iterations times and summarise the measured runs.
+ * Returns null when the file can't be checked.
+ */
+export async function runBenchmark(
+  filePath: string,
+  options: BenchmarkOptions
+): Promise<BenchmarkResult | null> {
+  const absolutePath = resolve(filePath);
+  if (!existsSync(absolutePath)) {
+    return null;
+  }
+
+  const samples: Record<BenchmarkPhase, number[]> = { setup: [], run: [], parse: [], total: [] };
+  let tool = '';
+
+  for (let i = 0; i < options.warmup + options.iterations; i++) {
+    const measured = i >= options.warmup;
+    const startedAt = performance.now();
+    const result = await checkFile(absolutePath, {
+      onPhase: (phase, durationMs) => {
+        if (measured) samples[phase].push(durationMs);
+      },
+    });
+    if (!result) {
+      return null;
+    }
+    tool = result.tool;
+    if (measured) {
+      samples.total.push(performance.now() - startedAt);
+    }
+  }
+
+  const phases = {} as BenchmarkResult['phases'];
+  for (const phase of PHASES) {
+    phases[phase] = Object.fromEntries(
+      PERCENTILES.map((p) => [`p${p}`, Math.round(percentile(samples[phase], p) * 10) / 10])
+    );
+  }
+
+  return {
+    file: relative(process.cwd(), absolutePath) || absolutePath,
+    tool,
+    iterations: options.iterations,
+    phases,
+  };
+}
+
+export function formatBenchmark(result: BenchmarkResult): string {
+  const header = ['Phase', ...PERCENTILES.map((p) => `p${p} ms`)];
+  const rows = PHASES.map((phase) => [
+    phase,
+    ...PERCENTILES.map((p) => (result.phases[phase][`p${p}`] ?? 0).toFixed(1)),
+  ]);
+  const line = (cells: string[]): string =>
+    cells.map((cell, i) => (i === 0 ? cell.padEnd(8) : cell.padStart(10))).join('');
+
+  return [
+    `Benchmark: ${result.file} (${result.tool}), ${result.iterations} iterations`,
+    line(header),
+    ...rows.map(line),
+  ].join('\n');
+}
@@ -1,9 +1,85 @@
-import { resolve } from 'path';
-import { existsSync } from 'fs';
-import { checkFile } from '../../file-checker';
-import { outputDiagnostics, type ShellDiagnostic } from '../../shell-integration';
+import { basename, extname, join, relative, resolve } from 'path';
+import {
+  appendFileSync,
+  existsSync,
+  mkdtempSync,
+  readFileSync,
+  rmSync,
+  writeFileSync,
+} from 'fs';
+import { randomUUID } from 'crypto';
+import { cpus, tmpdir } from 'os';
+import { checkFile, type CheckerOptions, type FileCheckResult } from '../../file-checker';
+import {
+  LANGUAGE_EXTENSIONS,
+  detectLanguage,
+  getLanguageForExtension,
+  type SupportedLanguage,
+} from '../../language-extensions';
+import { findProjectRoot } from '../../utils/common';
+import {
+  formatShellIntegrationOutput,
+  outputDiagnostics,
+  type ShellDiagnostic,
+} from '../../shell-integration';
+import { getFormatter } from '../formatters';
+import type { CheckOptions } from '../utils/check-options';
+import {
+  filterByCode,
+  filterByMinSeverity,
+  remapSeverity,
+  scoreImpact,
+  truncateDiagnostics,
+} from '../utils/diagnostic-filters';
+import { groupByCause } from '../utils/diagnostic-groups';
+import {
+  applyExcludes,
+  applyMaxFileSize,
+  applyTestFilter,
+  formatFileSize,
+  type OversizedFile,
+} from '../utils/file-filter';
+import { filterByBlame } from '../utils/git-changes';
+import { DiagnosticStore, historyPath } from '../utils/history';
+import { createProgressReporter } from '../utils/progress';
+import { extractContext, fenceSnippet } from '../utils/source-context';
+import { SUPPRESS_MARKER, filterSuppressed, insertSuppressions } from '../utils/suppressions';
 
-export async function runCheck(filePath: string): Promise<boolean> {
+// Files with dozens of diagnostics rarely benefit from seeing all of them at once
+const DEFAULT_MAX_DIAGNOSTICS_PER_FILE = 20;
+
+// Checkers are heavy processes (tsc, cargo, ...), so cap parallelism even on big machines
+const DEFAULT_CONCURRENCY = Math.min(cpus().length, 4);
+
+async function checkAndTrack(
+  absolutePath: string,
+  options: CheckOptions
+): ReturnType<typeof checkFile> {
+  let result: FileCheckResult | null;
+  const language = getLanguageForExtension(extname(absolutePath));
+  // --language overrides the checker picked from the extension (e.g. .go.tmpl files);
+  // extensionless scripts are checked as the language their shebang names
+  const checkAs = options.language ?? detectLanguage(absolutePath);
+  if (checkAs && checkAs !== language) {
+    const projectRoot = findProjectRoot(absolutePath);
+    result = await checkSourceAs(
+      readFileSync(absolutePath, 'utf8'),
+      checkAs,
+      projectRoot,
+      relative(projectRoot, absolutePath),
+      options
+    );
+  } else {
+    result = await checkFile(absolutePath, options);
+    if (result && language) {
+      result = { ...result, language };
+    }
+  }
+  // Results only carry project-relative paths, which can repeat across projects
+  return result ? { ...result, sourcePath: absolutePath } : null;
+}
+
+export async function runCheck(filePath: string, options: CheckOptions = {}): Promise<boolean> {
   if (!filePath) {
     return false;
   }
@@ -14,90 +90,430 @@ export async function runCheck(filePath: string): Promise<boolean> {
     return false;
   }
 
-  const result = await checkFile(absolutePath);
-  if (result === null) {
+  if (applyExcludes([absolutePath], options.exclude).skippedCount > 0) {
+    return reportResults([], options, 1);
+  }
+
+  const { tooLarge } = applyMaxFileSize([absolutePath], options.maxFileSize);
+  if (tooLarge.length > 0) {
+    return reportResults([], options, 0, tooLarge);
+  }
+
+  if (applyTestFilter([absolutePath], options.includeTests).testCount > 0) {
+    return reportResults([], options, 0, [], 1);
+  }
+
+  const progress = createProgressReporter(options.progress);
+  progress.start(`Checking ${relative(process.cwd(), absolutePath) || absolutePath}`);
+  let result: Awaited<ReturnType<typeof checkFile>>;
+  try {
+    result = await checkAndTrack(absolutePath, options);
+  } finally {
+    progress.done();
+  }
+
+  if (result === null && !isStructuredFormat(options)) {
     // Checking was disabled or file type not supported - exit silently
     return false;
-  } else if (result) {
-    // Convert to shell diagnostics format
-    const shellDiagnostics: ShellDiagnostic[] = result.diagnostics.map((diag) => ({
-      ...diag,
-      file: result.file,
-    }));
+  }
 
-    // Output using shell integration
-    if (shellDiagnostics.length > 0) {
-      outputDiagnostics(shellDiagnostics);
-      return true; // Has errors
-    } else {
-      // For check command, show "No issues found" when no errors
-      outputDiagnostics([]);
-      return false; // No errors
-    }
-  } else {
-    // File type not supported - exit silently
+  return reportResults(result ? [result] : [], options);
+}
+
+/**
+ * Check source as a file of the given language by writing it to a temp
+ * file with that language's extension. Checking runs against projectRoot
+ * and the temp path is reported as displayPath instead.
+ */
+async function checkSourceAs(
+  content: string,
+  language: SupportedLanguage,
+  projectRoot: string,
+  displayPath: string,
+  options: CheckerOptions
+): Promise<FileCheckResult | null> {
+  const tempDir = mkdtempSync(join(tmpdir(), 'claude-lsp-'));
+  const tempFile = join(tempDir, `source${LANGUAGE_EXTENSIONS[language][0]}`);
+
+  let result: FileCheckResult | null;
+  try {
+    writeFileSync(tempFile, content);
+    result = await checkFile(tempFile, options, projectRoot);
+  } finally {
+    rmSync(tempDir, { recursive: true, force: true });
+  }
+
+  if (!result) {
+    return null;
+  }
+  return {
+    ...result,
+    file: displayPath,
+    language,
+    diagnostics: result.diagnostics.map((diag) =>
+      diag.file?.endsWith(basename(tempFile)) ? { ...diag, file: displayPath } : diag
+    ),
+  };
+}
+
+// Reported in place of a file path when checking stdin
+const STDIN_PATH = '<stdin>';
+
+/**
+ * Check source read from stdin as a file of the given language, against
+ * the project in the current directory. Diagnostics are reported for <stdin>.
+ */
+export async function runCheckStdin(
+  content: string,
+  language: SupportedLanguage,
+  options: CheckOptions = {}
+): Promise<boolean> {
+  const projectRoot = findProjectRoot(join(process.cwd(), STDIN_PATH));
+  const result = await checkSourceAs(content, language, projectRoot, STDIN_PATH, options);
+
+  if (result === null && !isStructuredFormat(options)) {
     return false;
   }
+  return reportResults(result ? [result] : [], options);
 }
 
 /**
  * Check multiple files in parallel for better performance
  * Useful when Claude Code processes multiple files at once
  */
-export async function runCheckMultiple(filePaths: string[]): Promise<boolean> {
+export async function runCheckMultiple(
+  filePaths: string[],
+  options: CheckOptions = {}
+): Promise<boolean> {
   if (!filePaths || filePaths.length === 0) {
     return false;
   }
 
   // Filter to existing files first
-  const validFiles = filePaths.map((filePath) => resolve(filePath)).filter(existsSync);
+  const { files: includedFiles, skippedCount } = applyExcludes(
+    filePaths.map((filePath) => resolve(filePath)).filter(existsSync),
+    options.exclude
+  );
+  const { files: sizedFiles, tooLarge } = applyMaxFileSize(includedFiles, options.maxFileSize);
+  const { files: validFiles, testCount } = applyTestFilter(sizedFiles, options.includeTests);
 
   if (validFiles.length === 0) {
-    return false;
+    return skippedCount > 0 || tooLarge.length > 0 || testCount > 0
+      ? reportResults([], options, skippedCount, tooLarge, testCount)
+      : false;
   }
 
-  // Check files in parallel with limited concurrency to avoid overwhelming system
-  const MAX_CONCURRENT = 4;
+  // Check files in parallel with limited concurrency to avoid overwhelming system.
+  // Each worker picks up the next file as soon as it finishes one.
+  const concurrency = Math.min(options.concurrency ?? DEFAULT_CONCURRENCY, validFiles.length);
   const results: Array<{ file: string; result: Awaited<ReturnType<typeof checkFile>> }> = [];
+  let nextIndex = 0;
+
+  const worker = async (): Promise<void> => {
+    while (nextIndex < validFiles.length) {
+      const file = validFiles[nextIndex++];
+      if (!file) continue;
+      results.push({ file, result: await checkAndTrack(file, options) });
+      progress.update(`Checked ${results.length}/${validFiles.length} files`);
+    }
+  };
+
+  const progress = createProgressReporter(options.progress);
+  progress.start(`Checking ${validFiles.length} files`);
+  try {
+    await Promise.all(Array.from({ length: concurrency }, worker));
+  } finally {
+    progress.done();
+  }
+
+  // Checking was disabled or not supported for null results - skip them.
+  // Order by file name so output is deterministic regardless of completion order.
+  const checked = results
+    .map(({ result }) => result)
+    .filter((result): result is FileCheckResult => result !== null)
+    .sort((a, b) => a.file.localeCompare(b.file));
+
+  return reportResults(checked, options, skippedCount, tooLarge, testCount);
+}
+
+/**
+ * Check files like runCheckMultiple, returning the results as they would be
+ * reported instead of printing them (for serve and mcp)
+ */
+export async function collectResults(
+  filePaths: string[],
+  options: CheckOptions = {}
+): Promise<FileCheckResult[]> {
+  let results: FileCheckResult[] = [];
+  await runCheckMultiple(filePaths, {
+    ...options,
+    quiet: true,
+    progress: false,
+    onReport: (reported) => {
+      results = reported;
+    },
+  });
+  return results;
+}
+
+function isStructuredFormat(options: CheckOptions): boolean {
+  return !!options.format && options.format !== 'text';
+}
+
+/**
+ * Store a run in the history database, returning why it failed if it did
+ */
+function recordHistory(results: FileCheckResult[]): string | null {
+  let store: DiagnosticStore | undefined;
+  try {
+    store = new DiagnosticStore();
+    store.recordRun(randomUUID(), new Date(), results);
+    return null;
+  } catch (error) {
+    return error instanceof Error ? error.message : String(error);
+  } finally {
+    store?.close();
+  }
+}
+
+/**
+ * Write results in the requested format and report whether any diagnostics were found
+ */
+async function reportResults(
+  checked: FileCheckResult[],
+  options: CheckOptions,
+  skippedCount = 0,
+  tooLarge: OversizedFile[] = [],
+  skippedTests = 0
+): Promise<boolean> {
+  // Summary notes appended to text output (e.g. filtered diagnostic counts)
+  const notes: string[] = [];
+  let results =
+    options.contextLines !== undefined ? attachSnippets(checked, options.contextLines) : checked;
+
+  if (skippedCount > 0) {
+    notes.push(`Skipped ${skippedCount} files matching exclude patterns.`);
+  }
+  if (tooLarge.length > 0) {
+    const sizes = tooLarge.map(
+      ({ file, size }) => `${relative(process.cwd(), file) || file} (${formatFileSize(size)})`
+    );
+    notes.push(`Skipped (too large): ${sizes.join(', ')}. Use --max-file-size 0 to check them.`);
+  }
+  if (skippedTests > 0) {
+    notes.push(`Skipped ${skippedTests} Go test files. Use --include-tests to check them.`);
+  }
 
-  for (let i = 0; i < validFiles.length; i += MAX_CONCURRENT) {
-    const batch = validFiles.slice(i, i + MAX_CONCURRENT);
+  for (const result of results) {
+    if (result.timedOut) {
+      notes.push(`⚠ ${result.file}: ${result.tool} timed out. Command: ${result.command}`);
+    }
+  }
 
-    const batchResults = await Promise.all(
-      batch.map(async (file) => ({
-        file,
-        result: await checkFile(file),
-      }))
+  results = results.map((result) => {
+    const sourceLines = result.sourcePath ? readSourceLines(result.sourcePath) : null;
+    if (!sourceLines) {
+      return result;
+    }
+    const { diagnostics, stale } = filterSuppressed(result.diagnostics, sourceLines);
+    for (const suppression of stale) {
+      notes.push(
+        `⚠ ${result.file}:${suppression.commentLine}: ${SUPPRESS_MARKER} matches no diagnostic, consider removing it`
+      );
+    }
+    return { ...result, diagnostics };
+  });
+
+  if (options.blameSince) {
+    const blamed = await filterByBlame(
+      results,
+      (result) => result.sourcePath,
+      options.blameSince,
+      options.author
     );
+    results = blamed.results;
+    if (blamed.filteredCount > 0) {
+      notes.push(
+        `${blamed.filteredCount} diagnostics on lines unchanged since ${options.blameSince}, not shown.`
+      );
+    }
+  }
 
-    results.push(...batchResults);
+  if (options.severityOverrides) {
+    results = remapSeverity(results, options.severityOverrides);
   }
 
-  // Collect all diagnostics across all files
-  const allDiagnostics: ShellDiagnostic[] = [];
+  if (options.excludeCodes?.length) {
+    const filtered = filterByCode(results, options.excludeCodes);
+    results = filtered.results;
+    if (filtered.filteredCount > 0) {
+      notes.push(`Skipped ${filtered.filteredCount} diagnostics matching excluded codes.`);
+    }
+  }
 
-  for (const { result } of results) {
-    if (result === null) {
-      // Checking was disabled or not supported - skip
-      continue;
-    } else if (result && result.diagnostics.length > 0) {
-      // Add diagnostics with file context
-      const shellDiagnostics: ShellDiagnostic[] = result.diagnostics.map((diag) => ({
-        ...diag,
-        file: result.file,
-      }));
-      allDiagnostics.push(...shellDiagnostics);
+  // Recorded before severity filtering so the history doesn't depend on the terminal
+  const historyError = options.history ? recordHistory(results) : null;
+  if (historyError) {
+    notes.push(`⚠ Run not recorded in ${historyPath()}: ${historyError}`);
+  }
+
+  // Interactive terminals default to warnings and above
+  const minSeverity = options.minSeverity ?? (process.stderr.isTTY ? 2 : undefined);
+  if (minSeverity !== undefined) {
+    const filtered = filterByMinSeverity(results, minSeverity);
+    results = filtered.results;
+    if (filtered.filteredCount > 0) {
+      notes.push(`${filtered.filteredCount} diagnostics below threshold, not shown.`);
+    }
+  }
+  // Grouping and truncation only shorten the report; --suppress covers every diagnostic
+  const reportable = results;
+
+  // Pre-commit and CI runs only fail on errors; pre-commit also names every one of them
+  const errorsOnly = options.preCommit || options.ci;
+  const errors = errorsOnly
+    ? results.flatMap((result) =>
+        result.diagnostics
+          .filter((diag) => diag.severity === 'error')
+          .map((diag) => `${result.file}:${diag.line}: ${diag.message}`)
+      )
+    : [];
+
+  if (options.group) {
+    const grouped = groupByCause(results);
+    results = grouped.results;
+    if (grouped.groupedCount > 0) {
+      notes.push(`${grouped.groupedCount} diagnostics grouped with another sharing their cause.`);
+    }
+  }
+
+  results = scoreImpact(results);
+  const truncated = truncateDiagnostics(
+    results,
+    options.maxDiagnostics ?? DEFAULT_MAX_DIAGNOSTICS_PER_FILE
+  );
+  results = truncated.results;
+  if (truncated.omittedCount > 0) {
+    notes.push(`${truncated.omittedCount} additional lower-impact diagnostics omitted.`);
+  }
+
+  if (options.suppress) {
+    const inserted = suppressReported(reportable);
+    notes.push(`Inserted ${inserted} ${SUPPRESS_MARKER} comments; they apply from the next run.`);
+  }
+
+  const hasDiagnostics = errorsOnly
+    ? errors.length > 0
+    : results.some((result) => result.diagnostics.length > 0);
+  const blocking = options.preCommit ? errors : [];
+  options.onReport?.(results);
+  if (options.quiet) {
+    return hasDiagnostics;
+  }
+
+  const formatter = isStructuredFormat(options) ? getFormatter(options.format || '') : null;
+  if (formatter) {
+    const report = formatter.format(results) + '\n';
+    if (options.outputFile) {
+      writeOutputFile(report, options);
+    } else {
+      process.stdout.write(report);
     }
-    // Skip unsupported file types silently
+    writeBlocking(blocking);
+    return hasDiagnostics;
   }
 
-  // Output all diagnostics at once using shell integration
-  if (allDiagnostics.length > 0) {
-    outputDiagnostics(allDiagnostics);
-    return true; // Has errors
+  // Collect all diagnostics across all files with file context
+  const allDiagnostics: ShellDiagnostic[] = results.flatMap((result) =>
+    result.diagnostics.map((diag) => ({
+      ...diag,
+      file: result.file,
+    }))
+  );
+
+  if (options.outputFile) {
+    const { summary } = formatShellIntegrationOutput(allDiagnostics, false, options.maxDiagnostics);
+    writeOutputFile([summary, ...notes.map((note) => `  ${note}`)].join('\n') + '\n', options);
+    writeBlocking(blocking);
+    return hasDiagnostics;
+  }
+
+  // Output using shell integration - shows "No issues found" when there are no errors
+  outputDiagnostics(allDiagnostics, false, options.maxDiagnostics);
+  for (const note of notes) {
+    process.stderr.write(`\n  ${note}`);
+  }
+  writeBlocking(blocking);
+  return hasDiagnostics;
+}
+
+/**
+ * Write the whole report to --output-file at once, so JSON and other
+ * structured formats stay a single document
+ */
+function writeOutputFile(report: string, options: CheckOptions): void {
+  const outputFile = options.outputFile ?? '';
+  if (options.appendOutput) {
+    appendFileSync(outputFile, report);
   } else {
-    // For check command, show "No issues found" when no errors
-    outputDiagnostics([]);
-    return false; // No errors
+    writeFileSync(outputFile, report);
+  }
+}
+
+function writeBlocking(blocking: string[]): void {
+  if (blocking.length === 0) {
+    return;
+  }
+  process.stderr.write(`\n\nCommit blocked by ${blocking.length} errors:\n`);
+  for (const line of blocking) {
+    process.stderr.write(`  ${line}\n`);
+  }
+}
+
+/**
+ * Add a fenced source snippet to every diagnostic of files read from disk
+ */
+function attachSnippets(results: FileCheckResult[], contextLines: number): FileCheckResult[] {
+  return results.map((result) => {
+    const sourceLines = result.sourcePath ? readSourceLines(result.sourcePath) : null;
+    if (!sourceLines) {
+      return result;
+    }
+    return {
+      ...result,
+      diagnostics: result.diagnostics.map((diag) => ({
+        ...diag,
+        snippet: fenceSnippet(
+          result.sourcePath ?? result.file,
+          extractContext(sourceLines, diag.line, diag.line, contextLines)
+        ),
+      })),
+    };
+  });
+}
+
+/**
+ * Write a suppression comment above every diagnostic that passed the filters.
+ * Returns the number of comments inserted.
+ */
+function suppressReported(results: FileCheckResult[]): number {
+  let inserted = 0;
+  for (const result of results) {
+    const sourcePath = result.sourcePath;
+    const sourceLines = sourcePath ? readSourceLines(sourcePath) : null;
+    if (!sourcePath || !sourceLines || result.diagnostics.length === 0) continue;
+
+    const updated = insertSuppressions(sourceLines, result.diagnostics, sourcePath);
+    writeFileSync(sourcePath, updated.join('\n'));
+    inserted += updated.length - sourceLines.length;
+  }
+  return inserted;
+}
+
+function readSourceLines(file: string): string[] | null {
+  try {
+    return readFileSync(file, 'utf8').split('\n');
+  } catch {
+    return null;
   }
 }
@@ -0,0 +1,214 @@
+/**
+ * Shell completion scripts
+ *
+ * `claude-lsp-cli completion <shell>` prints a script for bash, zsh, fish
+ * or PowerShell, e.g. `source <(claude-lsp-cli completion bash)`.
+ * Flags and their values come from CHECK_FLAGS so completions follow the
+ * check command.
+ */
+
+import { LANGUAGE_EXTENSIONS } from '../../language-extensions';
+import { CHECK_FLAGS } from '../utils/check-options';
+
+export const COMPLETION_SHELLS = ['bash', 'zsh', 'fish', 'powershell'];
+
+const COMMANDS: Array<[string, string]> = [
+  ['hook', 'Handle Claude Code hook events'],
+  ['check', 'Check files for errors/warnings'],
+  ['disable', 'Disable language checking globally'],
+  ['enable', 'Enable language checking globally'],
+  ['config', 'Write common settings with config init'],
+  ['completion', 'Print a shell completion script'],
+  ['health', 'Diagnose installation problems'],
+  ['version', 'Print version and build metadata'],
+  ['benchmark', 'Measure checking latency per phase'],
+  ['serve', 'Check files over HTTP'],
+  ['mcp', 'Serve checks as an MCP tool'],
+  ['history', 'Browse runs recorded with check --history'],
+  ['help', 'Show help'],
+];
+
+// enable/disable take checker names; JavaScript shares the TypeScript checker
+const TOGGLE_LANGUAGES = [
+  ...Object.keys(LANGUAGE_EXTENSIONS).filter((language) => language !== 'javascript'),
+  'all',
+];
+
+const HOOK_EVENTS = ['PostToolUse'];
+
+function listValues(flagValue: readonly string[]): string {
+  return flagValue.join(' ');
+}
+
+function bashScript(): string {
+  const valueCases = CHECK_FLAGS.filter((flag) => flag.value)
+    .map((flag) => {
+      const reply =
+        flag.value === 'file'
+          ? 'COMPREPLY=($(compgen -f -- "$cur"))'
+          : flag.value === 'text'
+            ? 'COMPREPLY=()'
+            : `COMPREPLY=($(compgen -W "${listValues(flag.value ?? [])}" -- "$cur"))`;
+      return `    --${flag.name}) ${reply}; return ;;`;
+    })
+    .join('\n');
+
+  return `# bash completion for claude-lsp-cli
+_claude_lsp_cli() {
+  local cur="\${COMP_WORDS[COMP_CWORD]}"
+  local prev="\${COMP_WORDS[COMP_CWORD-1]}"
+
+  if [[ $COMP_CWORD -eq 1 ]]; then
+    COMPREPLY=($(compgen -W "${COMMANDS.map(([name]) => name).join(' ')}" -- "$cur"))
+    return
+  fi
+
+  case "$prev" in
+${valueCases}
+  esac
+
+  case "\${COMP_WORDS[1]}" in
+    check)
+      if [[ "$cur" == --* ]]; then
+        COMPREPLY=($(compgen -W "${CHECK_FLAGS.map((flag) => `--${flag.name}`).join(' ')}" -- "$cur"))
+      else
+        COMPREPLY=($(compgen -f -- "$cur"))
+      fi
+      ;;
+    enable|disable) COMPREPLY=($(compgen -W "${TOGGLE_LANGUAGES.join(' ')}" -- "$cur")) ;;
+    hook) COMPREPLY=($(compgen -W "${HOOK_EVENTS.join(' ')}" -- "$cur")) ;;
+    completion) COMPREPLY=($(compgen -W "${COMPLETION_SHELLS.join(' ')}" -- "$cur")) ;;
+  esac
+}
+complete -o default -F _claude_lsp_cli claude-lsp-cli
+`;
+}
+
+function zshScript(): string {
+  const flagSpecs = CHECK_FLAGS.map((flag) => {
+    const spec = `'*--${flag.name}[${flag.description}]`;
+    if (!flag.value) return `${spec}'`;
+    if (flag.value === 'file') return `${spec}:file:_files'`;
+    if (flag.value === 'text') return `${spec}:value: '`;
+    return `${spec}:value:(${listValues(flag.value)})'`;
+  });
+
+  return `#compdef claude-lsp-cli
+_claude_lsp_cli() {
+  local -a commands
+  commands=(
+${COMMANDS.map(([name, description]) => `    '${name}:${description}'`).join('\n')}
+  )
+
+  if (( CURRENT == 2 )); then
+    _describe 'command' commands
+    return
+  fi
+
+  case $words[2] in
+    check)
+      _arguments \\
+${flagSpecs.map((spec) => `        ${spec} \\`).join('\n')}
+        '*:file:_files'
+      ;;
+    enable|disable) _values 'language' ${TOGGLE_LANGUAGES.join(' ')} ;;
+    hook) _values 'event' ${HOOK_EVENTS.join(' ')} ;;
+    completion) _values 'shell' ${COMPLETION_SHELLS.join(' ')} ;;
+  esac
+}
+compdef _claude_lsp_cli claude-lsp-cli
+`;
+}
+
+function fishScript(): string {
+  const lines = [
+    '# fish completion for claude-lsp-cli',
+    'complete -c claude-lsp-cli -f',
+    ...COMMANDS.map(
+      ([name, description]) =>
+        `complete -c claude-lsp-cli -n __fish_use_subcommand -a ${name} -d '${description}'`
+    ),
+    ...CHECK_FLAGS.map((flag) => {
+      const base = `complete -c claude-lsp-cli -n '__fish_seen_subcommand_from check' -l ${flag.name}`;
+      const value =
+        flag.value === 'file'
+          ? ' -r -F'
+          : flag.value === 'text'
+            ? ' -x'
+            : flag.value
+              ? ` -x -a '${listValues(flag.value)}'`
+              : '';
+      return `${base}${value} -d '${flag.description}'`;
+    }),
+    "complete -c claude-lsp-cli -n '__fish_seen_subcommand_from check' -F",
+    `complete -c claude-lsp-cli -n '__fish_seen_subcommand_from enable disable' -a '${TOGGLE_LANGUAGES.join(' ')}'`,
+    `complete -c claude-lsp-cli -n '__fish_seen_subcommand_from hook' -a '${HOOK_EVENTS.join(' ')}'`,
+    `complete -c claude-lsp-cli -n '__fish_seen_subcommand_from completion' -a '${COMPLETION_SHELLS.join(' ')}'`,
+  ];
+  return lines.join('\n') + '\n';
+}
+
+function powershellList(values: readonly string[]): string {
+  return `@(${values.map((value) => `'${value}'`).join(', ')})`;
+}
+
+function powershellScript(): string {
+  const flagValues = CHECK_FLAGS.filter((flag) => Array.isArray(flag.value))
+    .map((flag) => `    '--${flag.name}' = ${powershellList(flag.value as readonly string[])}`)
+    .join('\n');
+
+  return `# PowerShell completion for claude-lsp-cli
+Register-ArgumentCompleter -Native -CommandName claude-lsp-cli -ScriptBlock {
+  param($wordToComplete, $commandAst, $cursorPosition)
+
+  $commands = ${powershellList(COMMANDS.map(([name]) => name))}
+  $flags = ${powershellList(CHECK_FLAGS.map((flag) => `--${flag.name}`))}
+  $flagValues = @{
+${flagValues}
+  }
+  $subcommandValues = @{
+    'enable' = ${powershellList(TOGGLE_LANGUAGES)}
+    'disable' = ${powershellList(TOGGLE_LANGUAGES)}
+    'hook' = ${powershellList(HOOK_EVENTS)}
+    'completion' = ${powershellList(COMPLETION_SHELLS)}
+  }
+
+  $elements = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })
+  if ($wordToComplete) { $elements = $elements[0..($elements.Count - 2)] }
+
+  if ($elements.Count -le 1) {
+    $candidates = $commands
+  } elseif ($flagValues.ContainsKey($elements[-1])) {
+    $candidates = $flagValues[$elements[-1]]
+  } elseif ($elements[1] -eq 'check' -and $wordToComplete -like '--*') {
+    $candidates = $flags
+  } elseif ($subcommandValues.ContainsKey($elements[1])) {
+    $candidates = $subcommandValues[$elements[1]]
+  } else {
+    return
+  }
+
+  $candidates | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+    [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+  }
+}
+`;
+}
+
+/**
+ * Return the completion script for a shell, or null if it isn't supported
+ */
+export function completionScript(shell: string): string | null {
+  switch (shell) {
+    case 'bash':
+      return bashScript();
+    case 'zsh':
+      return zshScript();
+    case 'fish':
+      return fishScript();
+    case 'powershell':
+      return powershellScript();
+    default:
+      return null;
+  }
+}
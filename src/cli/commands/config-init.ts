@@ -0,0 +1,117 @@
+/**
+ * Config init command - first-time setup
+ *
+ * `claude-lsp-cli config init` asks for the common check settings and
+ * merges them into the global config, or into `.claude-lsp.json` with
+ * --project. --non-interactive writes the defaults without asking.
+ */
+
+import { join } from 'path';
+import { findProjectRoot, getConfigPath } from '../../utils/common';
+import { OUTPUT_FORMATS } from '../formatters';
+import { PROJECT_CONFIG_FILE } from '../utils/check-config';
+import { parseSeverityLevel } from '../utils/diagnostic-filters';
+import { findUnusableToolPath } from '../utils/tool-paths';
+import { updateConfig } from './config';
+import { LANGUAGE_TOOLS } from './help';
+
+export type Ask = (_question: string) => Promise<string>;
+
+export interface ConfigInitOptions {
+  interactive: boolean;
+  /** Write <project root>/.claude-lsp.json instead of the global config */
+  project: boolean;
+}
+
+const DEFAULTS = { format: 'text', minSeverity: 'warning', exclude: [] as string[] };
+
+/**
+ * Ask until the answer parses; an empty answer takes the default
+ */
+async function askValid<T>(
+  ask: Ask,
+  question: string,
+  defaultValue: string,
+  parse: (_answer: string) => T | null
+): Promise<T> {
+  const suffix = defaultValue ? ` [${defaultValue}]` : '';
+  for (;;) {
+    const answer = (await ask(`${question}${suffix}: `)).trim() || defaultValue;
+    const value = parse(answer);
+    if (value !== null) {
+      return value;
+    }
+    console.error(`  Invalid value: ${answer}`);
+  }
+}
+
+async function askSettings(ask: Ask): Promise<Record<string, unknown>> {
+  const format = await askValid(
+    ask,
+    `Default output format (${OUTPUT_FORMATS.join(', ')})`,
+    DEFAULTS.format,
+    (answer) => (OUTPUT_FORMATS.includes(answer) ? answer : null)
+  );
+  const minSeverity = await askValid(
+    ask,
+    'Least severe level to report (error, warning, information, hint)',
+    DEFAULTS.minSeverity,
+    (answer) => (parseSeverityLevel(answer) !== null ? answer.toLowerCase() : null)
+  );
+  const exclude = await askValid(
+    ask,
+    "Glob patterns of files to skip, comma-separated (e.g. 'vendor/**, *.pb.go')",
+    'none',
+    (answer) =>
+      answer === 'none'
+        ? []
+        : answer
+            .split(',')
+            .map((pattern) => pattern.trim())
+            .filter(Boolean)
+  );
+
+  const settings: Record<string, unknown> = { format, minSeverity, exclude };
+
+  const missing = LANGUAGE_TOOLS.filter((lang) => !Bun.which(lang.command));
+  const wantsToolPaths =
+    missing.length > 0 &&
+    (await askValid(
+      ask,
+      `Set paths for checkers not on PATH (${missing.map((lang) => lang.command).join(', ')})? y/n`,
+      'n',
+      (answer) => (/^[yn]/i.test(answer) ? /^y/i.test(answer) : null)
+    ));
+  if (wantsToolPaths) {
+    const toolPaths: Record<string, string> = {};
+    for (const lang of missing) {
+      const path = await askValid(ask, `Path to ${lang.command} (Enter to skip)`, '', (answer) =>
+        !answer || !findUnusableToolPath({ [lang.command]: answer }) ? answer : null
+      );
+      if (path) {
+        toolPaths[lang.command] = path;
+      }
+    }
+    if (Object.keys(toolPaths).length > 0) {
+      settings.toolPaths = toolPaths;
+    }
+  }
+
+  return settings;
+}
+
+/**
+ * Write the chosen settings and return the config file path
+ */
+export async function runConfigInit(
+  options: ConfigInitOptions,
+  ask: Ask,
+  cwd: string = process.cwd()
+): Promise<string> {
+  const configPath = options.project
+    ? join(findProjectRoot(join(cwd, PROJECT_CONFIG_FILE)), PROJECT_CONFIG_FILE)
+    : getConfigPath();
+  const settings = options.interactive ? await askSettings(ask) : { ...DEFAULTS };
+  updateConfig(settings, configPath);
+  return configPath;
+}
@@ -1,11 +1,11 @@
-import { join, dirname } from 'path';
+import { dirname } from 'path';
 import { existsSync, mkdirSync, readFileSync, writeFileSync, rmSync, renameSync } from 'fs';
+import { getConfigPath } from '../../utils/common';
 import { showStatus } from './help';
 
 // Use a hybrid approach: Bun APIs where they provide clear benefits
 export function loadConfig(): Record<string, unknown> {
-  const homeDir = process.env.HOME || process.env.USERPROFILE || '';
-  const configPath = join(homeDir, '.claude', 'lsp-config.json');
+  const configPath = getConfigPath();
   let config: Record<string, unknown> = {};
 
   if (existsSync(configPath)) {
@@ -19,9 +19,13 @@ export function loadConfig(): Record<string, unknown> {
   return config;
 }
 
-function updateConfig(updates: Record<string, unknown>): void {
-  const homeDir = process.env.HOME || process.env.USERPROFILE || '';
-  const configPath = join(homeDir, '.claude', 'lsp-config.json');
+/**
+ * Merge updates into a JSON config file (the global config by default)
+ */
+export function updateConfig(
+  updates: Record<string, unknown>,
+  configPath: string = getConfigPath()
+): void {
   let config: Record<string, unknown> = {};
 
   // Read existing config
@@ -0,0 +1,121 @@
+/**
+ * Health command - diagnose installation problems
+ *
+ * `claude-lsp-cli health` checks the config files, configured tool paths,
+ * the checkers the current project needs and the temp directory, printing
+ * ✓ or ✗ for each with a hint on how to fix failures.
+ */
+
+import { mkdtempSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import { LANGUAGE_REGISTRY, findLocalTool } from '../../language-checker-registry';
+import { LANGUAGE_EXTENSIONS, isSupportedLanguage } from '../../language-extensions';
+import { execCommand, findProjectRoot, isLanguageDisabled } from '../../utils/common';
+import { loadCheckConfig } from '../utils/check-config';
+import { findUnusableToolPath } from '../utils/tool-paths';
+import { LANGUAGE_TOOLS } from './help';
+
+// Ensures every checker is registered before looking up project languages
+import '../../checkers/index';
+
+export interface HealthCheck {
+  name: string;
+  ok: boolean;
+  /** How to fix a failed check */
+  hint?: string;
+}
+
+async function runsVersion(command: string, versionArg: string): Promise<boolean> {
+  try {
+    return (await execCommand([command, versionArg])).exitCode === 0;
+  } catch {
+    return false;
+  }
+}
+
+function versionArgFor(tool: string): string {
+  return LANGUAGE_TOOLS.find((lang) => lang.command === tool)?.versionArg ?? '--version';
+}
+
+/**
+ * Run every health check for the project containing cwd
+ */
+export async function runHealthChecks(cwd: string = process.cwd()): Promise<HealthCheck[]> {
+  const checks: HealthCheck[] = [];
+
+  const warnings: string[] = [];
+  const options = loadCheckConfig(cwd, (message) => warnings.push(message));
+  checks.push({
+    name: 'Config files',
+    ok: warnings.length === 0,
+    hint: warnings.join('\n'),
+  });
+
+  const toolPaths = options.toolPaths ?? {};
+  for (const [tool, path] of Object.entries(toolPaths)) {
+    const unusable = findUnusableToolPath({ [tool]: path });
+    const ok = !unusable && (await runsVersion(path, versionArgFor(tool)));
+    checks.push({
+      name: `Tool path ${tool} (${path})`,
+      ok,
+      hint: unusable ?? `${path} ${versionArgFor(tool)} failed; check the toolPaths config`,
+    });
+  }
+
+  // Only the checkers this project needs: missing tools for other languages are fine
+  const projectRoot = findProjectRoot(join(cwd, 'health'));
+  for (const lang of LANGUAGE_TOOLS) {
+    const extension = isSupportedLanguage(lang.code) ? LANGUAGE_EXTENSIONS[lang.code][0] : '';
+    const config = extension ? LANGUAGE_REGISTRY.get(extension) : undefined;
+    if (!config?.detectConfig?.(projectRoot) || isLanguageDisabled(projectRoot, config.name)) {
+      continue;
+    }
+    const command =
+      toolPaths[lang.command] ?? findLocalTool(projectRoot, config.localPaths) ?? lang.command;
+    checks.push({
+      name: `${lang.name} checker (${command})`,
+      ok: await runsVersion(command, lang.versionArg),
+      hint: lang.install,
+    });
+  }
+
+  const tempRoot = tmpdir();
+  let tempWritable = true;
+  try {
+    const dir = mkdtempSync(join(tempRoot, 'claude-lsp-health-'));
+    writeFileSync(join(dir, 'probe'), '');
+    rmSync(dir, { recursive: true, force: true });
+  } catch {
+    tempWritable = false;
+  }
+  checks.push({
+    name: `Temp directory writable (${tempRoot})`,
+    ok: tempWritable,
+    hint: 'Set TMPDIR to a writable directory; --stdin and --language need it',
+  });
+
+  return checks;
+}
+
+export function formatHealthReport(checks: HealthCheck[]): string {
+  const lines = ['Health check:'];
+  for (const check of checks) {
+    lines.push(`  ${check.ok ? '✓' : '✗'} ${check.name}`);
+    if (!check.ok && check.hint) {
+      lines.push(...check.hint.split('\n').map((line) => `      ${line}`));
+    }
+  }
+  return lines.join('\n');
+}
+
+/**
+ * Print the health report and return whether every check passed
+ */
+export async function runHealth(
+  log: (..._args: unknown[]) => unknown = console.log
+): Promise<boolean> {
+  const checks = await runHealthChecks();
+  log(formatHealthReport(checks));
+  return checks.every((check) => check.ok);
+}
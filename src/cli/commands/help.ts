@@ -10,10 +10,96 @@ Usage: claude-lsp-cli <command> [args]
 
 Commands:
   hook <event>             Handle Claude Code hook events
-  check <file>             Check individual file for errors/warnings
+  check <file|dir>         Check files for errors/warnings (a directory checks
+                           the source files directly inside it)
   disable <language>       Disable language checking globally (e.g. disable scala)
   enable <language>        Enable language checking globally (e.g. enable scala)
+  config init              Ask for common check settings and write them to the global
+                           config (--project: .claude-lsp.json; --non-interactive)
+  completion <shell>       Print a completion script: bash, zsh, fish, powershell
+  health                   Check config, tool paths and this project's checkers
+  version [--format json]  Print version, commit, build date and runtime
+  benchmark <file>         Check a file repeatedly and print p50/p95/p99 per phase
+                           (--iterations N, default 10; --warmup N, default 1;
+                           --format json)
+  serve                    Check files over HTTP: POST /analyze, GET /health, /metrics,
+                           POST /config/reload (config is reread on every request)
+                           (--port 7777, --host 127.0.0.1, --server-token <token>)
+  mcp                      Serve an analyze_file tool over MCP stdio (Claude Desktop)
+  history list|show <id>   List runs recorded with check --history (--since 7d,
+                           --file path) or show one run's diagnostics
+  history trend            Errors and warnings per day, week or month (--since 30d,
+                           --group-by file|package|code, --format json)
   help                     Show this help message
+
+Check options:
+  --format <format>        Output format: text (default), json, github, markdown,
+                           sarif, junit, html
+                           (github is the default when GITHUB_ACTIONS=true)
+  --min-severity <level>   Only report diagnostics at or above a level:
+                           1/error, 2/warning, 3/information, 4/hint
+                           (default: warning in a terminal, everything otherwise)
+  --config <path>          Use an alternative config file (or set CLAUDE_LSP_CONFIG)
+  --watch                  Re-check files whenever they are saved (and all Go files
+                           of a module when go.mod or go.sum changes)
+  --max-diagnostics <n>    Report at most n diagnostics per file, highest impact first
+                           (severity, then how many diagnostics share the symbol)
+                           (default: 20; text output otherwise lists the first 5)
+  --progress               Log progress lines even when stderr is not a terminal
+  --no-progress            Hide the progress spinner
+  --no-color               Write no ANSI escape codes (also NO_COLOR=1 or TERM=dumb)
+  --context-lines [n]      Show n source lines around each diagnostic (default: 10)
+  --timeout <duration>     Stop a checker that runs longer than this, e.g. 90s or 2m
+                           (default: 30s; some checkers set their own limit)
+  --tool-path <tool=path>  Run this executable for a checker tool (repeatable),
+                           e.g. go=/usr/local/go/bin/go or pyright=./bin/pyright
+  --exclude <glob>         Skip matching files, e.g. 'vendor/**' or '*.pb.go' (repeatable)
+  --exclude-code <codes>   Drop diagnostics with these codes, comma-separated (e.g.
+                           SA1006,TS6133), before --min-severity (repeatable)
+  --max-file-size <size>   Skip files larger than size, e.g. 2MB (default: 512KB;
+                           0 checks every file); skipped files are listed with sizes
+  --stdin                  Check source read from stdin, reported as <stdin>
+  --language <language>    Check files as this language instead of by extension
+                           (required with --stdin), e.g. go, typescript, python
+  --since-commit [ref]     Only check files changed between ref and HEAD
+                           (ref defaults to $GITHUB_BASE_REF in pull requests; an
+                           existing path after the flag is read as a file to check)
+  --blame-since <ref>      Only report diagnostics on lines you changed since ref
+                           (commits in ref..HEAD by --author, or uncommitted)
+  --author <email>         Author for --blame-since (default: git config user.email)
+  --pre-commit             Only check staged files; exit 1 only on errors, listing each
+                           (run from .git/hooks/pre-commit)
+  --ci                     Use CI defaults: github format on GitHub Actions, junit on
+                           Jenkins, errors only, info logging, no color or spinner;
+                           exit 1 only on errors
+  --manifest <file>        Also check the paths listed in file, one per line
+  --concurrency <n>        Check up to n files at once (default: CPU count, at most 4)
+  --group                  Report diagnostics with the same code and symbol once, with
+                           a count and the other locations (e.g. one
+                           "undefined: models.User" for 30 uses)
+  --suppress               Add a claude-lsp-ignore comment above each reported diagnostic
+  --build-tags <tags>      Go build tags, comma-separated (e.g. integration,linux), so
+                           files behind //go:build lines are checked as they'd build
+  --mod <mode>             Go module mode: vendor, mod, readonly (default: vendor when
+                           vendor/modules.txt exists, else the go tool's own default)
+  --include-tests          Also check Go *_test.go files, with go vet on their package
+                           so testing helpers resolve (skipped by default)
+  --history                Record the run's diagnostics (before --min-severity) in
+                           ~/.claude/lsp-history.db ($CLAUDE_LSP_HISTORY_DB)
+  --quiet                  Print nothing; exit 1 if diagnostics were found (for scripts);
+                           --suppress still inserts its comments
+  --verbose                Trace each checker command (→), its output (←), its stderr
+                           and phase timings to stderr, e.g. when a file with known
+                           errors reports none
+  --output-file <path>     Write the report (any format) to path, replacing it; the
+                           JSON formats stay one complete document
+  --append-output          Append to --output-file instead of replacing it
+  --log-level <level>      Log checker activity: debug, info, warn, error (default: off)
+  --log-file <path>        Append log entries to path instead of stderr
+  --log-format <format>    Log format: text (default), json (one object per line)
+  --profile <kind[=path]>  Write a cpu (sampling report) or mem (heap snapshot) profile;
+                           repeatable, e.g. --profile cpu --profile mem=heap.json;
+                           mem also logs heap usage every 10s at --log-level info
 `;
   const status = await showStatus();
   const fullMessage = helpText + status;
@@ -24,6 +110,75 @@ Commands:
   return fullMessage;
 }
 
+// Language tools and how to check they are installed (matching actual file checker commands)
+export const LANGUAGE_TOOLS = [
+  {
+    name: 'TypeScript',
+    code: 'typescript',
+    command: 'tsc',
+    versionArg: '--version',
+    install: 'npm install -g typescript',
+  },
+  {
+    name: 'Python',
+    code: 'python',
+    command: 'pyright',
+    versionArg: '--version',
+    install: 'npm install -g pyright',
+  },
+  {
+    name: 'Go',
+    code: 'go',
+    command: 'go',
+    versionArg: 'version',
+    install: 'Install Go from https://golang.org',
+  },
+  {
+    name: 'Rust',
+    code: 'rust',
+    command: 'rustc',
+    versionArg: '--version',
+    install: 'Install Rust from https://rustup.rs',
+  },
+  {
+    name: 'Java',
+    code: 'java',
+    command: 'javac',
+    versionArg: '-version',
+    install: 'Install Java JDK',
+  },
+  {
+    name: 'C/C++',
+    code: 'cpp',
+    command: 'gcc',
+    versionArg: '--version',
+    install: 'Install GCC or Clang',
+  },
+  { name: 'PHP', code: 'php', command: 'php', versionArg: '--version', install: 'Install PHP' },
+  {
+    name: 'Scala',
+    code: 'scala',
+    command: 'scalac',
+    versionArg: '-version',
+    install: 'Install Scala',
+  },
+  { name: 'Lua', code: 'lua', command: 'luac', versionArg: '-v', install: 'Install Lua' },
+  {
+    name: 'Elixir',
+    code: 'elixir',
+    command: 'elixir',
+    versionArg: '--version',
+    install: 'Install Elixir',
+  },
+  {
+    name: 'Terraform',
+    code: 'terraform',
+    command: 'terraform',
+    versionArg: 'version',
+    install: 'Install Terraform',
+  },
+];
+
 export async function showStatus(
   log: (..._args: unknown[]) => unknown = console.log
 ): Promise<string> {
@@ -58,77 +213,8 @@ Current Status:
     messages.push('  🚫 All language checking is DISABLED via config');
   }
 
-  // Check which language tools are available (matching actual file checker commands)
-  const languages = [
-    {
-      name: 'TypeScript',
-      code: 'typescript',
-      command: 'tsc',
-      versionArg: '--version',
-      install: 'npm install -g typescript',
-    },
-    {
-      name: 'Python',
-      code: 'python',
-      command: 'pyright',
-      versionArg: '--version',
-      install: 'npm install -g pyright',
-    },
-    {
-      name: 'Go',
-      code: 'go',
-      command: 'go',
-      versionArg: 'version',
-      install: 'Install Go from https://golang.org',
-    },
-    {
-      name: 'Rust',
-      code: 'rust',
-      command: 'rustc',
-      versionArg: '--version',
-      install: 'Install Rust from https://rustup.rs',
-    },
-    {
-      name: 'Java',
-      code: 'java',
-      command: 'javac',
-      versionArg: '-version',
-      install: 'Install Java JDK',
-    },
-    {
-      name: 'C/C++',
-      code: 'cpp',
-      command: 'gcc',
-      versionArg: '--version',
-      install: 'Install GCC or Clang',
-    },
-    { name: 'PHP', code: 'php', command: 'php', versionArg: '--version', install: 'Install PHP' },
-    {
-      name: 'Scala',
-      code: 'scala',
-      command: 'scalac',
-      versionArg: '-version',
-      install: 'Install Scala',
-    },
-    { name: 'Lua', code: 'lua', command: 'luac', versionArg: '-v', install: 'Install Lua' },
-    {
-      name: 'Elixir',
-      code: 'elixir',
-      command: 'elixir',
-      versionArg: '--version',
-      install: 'Install Elixir',
-    },
-    {
-      name: 'Terraform',
-      code: 'terraform',
-      command: 'terraform',
-      versionArg: 'version',
-      install: 'Install Terraform',
-    },
-  ];
-
   // Check all languages in parallel, then display in order
-  const checks = languages.map(async (lang) => {
+  const checks = LANGUAGE_TOOLS.map(async (lang) => {
     try {
       // Use utility function to prevent zombies
       const { exitCode } = await execCommand([lang.command, lang.versionArg]);
@@ -0,0 +1,279 @@
+/**
+ * History command - browse runs recorded with `check --history`
+ *
+ *   history list [--since 7d|2024-05-01] [--file path]
+ *   history show <run ID or prefix>
+ *   history trend [--since 7d] [--group-by file|package|code] [--format json]
+ */
+
+import { dirname, relative } from 'path';
+import type { DiagnosticStore, FileCheck, HistoryDiagnostic, RunSummary } from '../utils/history';
+
+// Run IDs are UUIDs; this much of one is unique in any realistic history
+const SHORT_ID_LENGTH = 8;
+
+const SINCE_UNITS: Record<string, number> = { m: 60000, h: 3600000, d: 86400000, w: 604800000 };
+
+const TREND_GROUPS = ['file', 'package', 'code'] as const;
+export type TrendGroup = (typeof TREND_GROUPS)[number];
+
+export type TrendPeriod = 'day' | 'week' | 'month';
+
+export interface HistoryArgs {
+  subcommand: 'list' | 'show' | 'trend';
+  /** Run ID or prefix for show */
+  id?: string;
+  /** Unix time in milliseconds; list and trend start here */
+  since: number;
+  file?: string;
+  /** Trend rows per period and file, package (directory) or code */
+  groupBy?: TrendGroup;
+  format?: 'table' | 'json';
+}
+
+export interface TrendRow {
+  /** First day of the period, or its month (2024-05) */
+  date: string;
+  group?: string;
+  errors: number;
+  warnings: number;
+}
+
+export interface ParsedHistoryArgs {
+  args?: HistoryArgs;
+  error?: string;
+}
+
+/**
+ * Parse a --since value: a duration back from now (30m, 12h, 7d, 2w) or a date
+ */
+export function parseSince(value: string, now: number = Date.now()): number | null {
+  const match = value.trim().match(/^(\d+)([mhdw])$/);
+  if (match?.[1] && match[2]) {
+    return now - parseInt(match[1], 10) * (SINCE_UNITS[match[2]] ?? 0);
+  }
+  const date = Date.parse(value);
+  return Number.isNaN(date) ? null : date;
+}
+
+function isTrendFormat(value: string | undefined): value is 'table' | 'json' {
+  return value === 'table' || value === 'json';
+}
+
+export function parseHistoryArgs(args: string[], now: number = Date.now()): ParsedHistoryArgs {
+  const [subcommand, ...rest] = args;
+  if (subcommand !== 'list' && subcommand !== 'show' && subcommand !== 'trend') {
+    return { error: `Unknown history command: ${subcommand ?? '(missing)'}` };
+  }
+
+  const parsed: HistoryArgs = { subcommand, since: 0 };
+  for (let i = 0; i < rest.length; i++) {
+    const arg = rest[i] ?? '';
+    if (!arg.startsWith('--')) {
+      if (subcommand !== 'show' || parsed.id) {
+        return { error: `Unexpected argument: ${arg}` };
+      }
+      parsed.id = arg;
+      continue;
+    }
+    const [flag = '', inline] = arg.split('=', 2);
+    const value = inline ?? rest[++i];
+    const since = flag === '--since' && value ? parseSince(value, now) : null;
+    const groupBy = TREND_GROUPS.find((group) => group === value);
+    if (subcommand !== 'show' && since !== null) {
+      parsed.since = since;
+    } else if (subcommand === 'list' && flag === '--file' && value) {
+      parsed.file = value;
+    } else if (subcommand === 'trend' && flag === '--group-by' && groupBy) {
+      parsed.groupBy = groupBy;
+    } else if (subcommand === 'trend' && flag === '--format' && isTrendFormat(value)) {
+      parsed.format = value;
+    } else {
+      return { error: `Invalid option: ${arg}${inline ? '' : ` ${value ?? ''}`}` };
+    }
+  }
+
+  if (subcommand === 'show' && !parsed.id) {
+    return { error: 'history show requires a run ID' };
+  }
+  return { args: parsed };
+}
+
+function formatTime(ms: number): string {
+  return new Date(ms).toISOString().replace('T', ' ').slice(0, 19);
+}
+
+/**
+ * One row per run: short ID, start time (UTC) and totals by severity
+ */
+export function formatRuns(runs: RunSummary[]): string {
+  if (runs.length === 0) {
+    return 'No runs recorded. Record one with: claude-lsp-cli check --history <files>';
+  }
+  const header = [
+    'ID'.padEnd(SHORT_ID_LENGTH),
+    'Started (UTC)'.padEnd(19),
+    'Files',
+    'Errors',
+    'Warnings',
+    'Info',
+  ].join('  ');
+  const rows = runs.map((run) =>
+    [
+      run.runId.slice(0, SHORT_ID_LENGTH).padEnd(SHORT_ID_LENGTH),
+      formatTime(run.startedAt),
+      String(run.files).padStart(5),
+      String(run.errors).padStart(6),
+      String(run.warnings).padStart(8),
+      String(run.infos).padStart(4),
+    ].join('  ')
+  );
+  return [header, ...rows].join('\n');
+}
+
+/**
+ * Days for a week's range, weeks up to about six months, months beyond
+ */
+export function trendPeriod(since: number, now: number): TrendPeriod {
+  const days = (now - since) / 86400000;
+  if (days <= 31) {
+    return 'day';
+  }
+  return days <= 183 ? 'week' : 'month';
+}
+
+function periodStart(ms: number, period: TrendPeriod): string {
+  const date = new Date(ms);
+  if (period === 'month') {
+    return date.toISOString().slice(0, 7);
+  }
+  if (period === 'week') {
+    // Weeks start on Monday
+    date.setUTCDate(date.getUTCDate() - ((date.getUTCDay() + 6) % 7));
+  }
+  return date.toISOString().slice(0, 10);
+}
+
+/**
+ * Totals per period, from the last time each file was checked in it: a file
+ * checked ten times a day counts once, with the state it was left in
+ */
+export function buildTrend(
+  checks: FileCheck[],
+  period: TrendPeriod,
+  groupBy?: TrendGroup,
+  cwd: string = process.cwd()
+): TrendRow[] {
+  // Checks are oldest first, so later ones replace earlier ones
+  const latest = new Map<string, FileCheck>();
+  for (const check of checks) {
+    latest.set(`${periodStart(check.startedAt, period)}\0${check.file}`, check);
+  }
+
+  const rows = new Map<string, TrendRow>();
+  const zero = { errors: 0, warnings: 0 };
+  const add = (date: string, group: string | undefined, counts: FileCheck['counts']) => {
+    const key = `${date}\0${group ?? ''}`;
+    const row = rows.get(key) ?? { date, ...(group === undefined ? {} : { group }), ...zero };
+    for (const { severity, n } of counts) {
+      if (severity === 'error') {
+        row.errors += n;
+      } else if (severity === 'warning') {
+        row.warnings += n;
+      }
+    }
+    rows.set(key, row);
+  };
+  for (const check of latest.values()) {
+    const date = periodStart(check.startedAt, period);
+    const file = relative(cwd, check.file) || check.file;
+    if (groupBy === 'code') {
+      for (const count of check.counts) {
+        add(date, count.code ?? '(none)', [count]);
+      }
+    } else if (groupBy) {
+      add(date, groupBy === 'package' ? dirname(file) : file, check.counts);
+    } else {
+      add(date, undefined, check.counts);
+    }
+  }
+
+  return [...rows.values()].sort(
+    (a, b) => a.date.localeCompare(b.date) || (a.group ?? '').localeCompare(b.group ?? '')
+  );
+}
+
+/**
+ * One row per period (and group): errors and warnings
+ */
+export function formatTrend(rows: TrendRow[], groupBy?: TrendGroup): string {
+  if (rows.length === 0) {
+    return 'No runs recorded. Record one with: claude-lsp-cli check --history <files>';
+  }
+  const label = groupBy ? groupBy.charAt(0).toUpperCase() + groupBy.slice(1) : '';
+  const width = Math.max(label.length, ...rows.map((row) => row.group?.length ?? 0));
+  const group = (value: string) => (groupBy ? [value.padEnd(width)] : []);
+  const header = ['Date'.padEnd(10), ...group(label), 'Errors', 'Warnings'].join('  ');
+  const lines = rows.map((row) =>
+    [
+      row.date.padEnd(10),
+      ...group(row.group ?? ''),
+      String(row.errors).padStart(6),
+      String(row.warnings).padStart(8),
+    ].join('  ')
+  );
+  return [header, ...lines].join('\n');
+}
+
+/**
+ * A run's diagnostics, as check prints them: file:line: severity message
+ */
+export function formatRun(
+  run: { runId: string; startedAt: number },
+  diagnostics: HistoryDiagnostic[],
+  cwd: string = process.cwd()
+): string {
+  const lines = [`Run ${run.runId} at ${formatTime(run.startedAt)} UTC`];
+  if (diagnostics.length === 0) {
+    lines.push('No diagnostics');
+  }
+  for (const diag of diagnostics) {
+    const code = diag.code ? ` [${diag.code}]` : '';
+    const file = relative(cwd, diag.file) || diag.file;
+    lines.push(`  ${file}:${diag.line}: ${diag.severity}${code} ${diag.message} (${diag.tool})`);
+  }
+  return lines.join('\n');
+}
+
+/**
+ * Answer a history command from the store. Returns what to print, or an error.
+ */
+export function runHistory(
+  args: HistoryArgs,
+  store: DiagnosticStore,
+  now: number = Date.now()
+): { output?: string; error?: string } {
+  if (args.subcommand === 'list') {
+    return { output: formatRuns(store.queryTrend(args.file, new Date(args.since))) };
+  }
+  if (args.subcommand === 'trend') {
+    const checks = store.fileChecks(new Date(args.since));
+    // Without --since the range starts at the first recorded run
+    const since = args.since || (checks[0]?.startedAt ?? now);
+    const rows = buildTrend(checks, trendPeriod(since, now), args.groupBy);
+    const output =
+      args.format === 'json' ? JSON.stringify(rows, null, 2) : formatTrend(rows, args.groupBy);
+    return { output };
+  }
+
+  const id = args.id ?? '';
+  const runs = store.findRuns(id);
+  const [run] = runs;
+  if (!run) {
+    return { error: `No run with ID ${id}` };
+  }
+  if (runs.length > 1) {
+    return { error: `Run ID ${id} is ambiguous: ${runs.map((r) => r.runId).join(', ')}` };
+  }
+  return { output: formatRun(run, store.runDiagnostics(run.runId)) };
+}
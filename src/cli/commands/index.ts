@@ -1,4 +1,13 @@
-export { runCheck, runCheckMultiple } from './check';
+export { runCheck, runCheckMultiple, runCheckStdin } from './check';
+export { runWatch } from './watch';
+export { completionScript, COMPLETION_SHELLS } from './completion';
 export { enableLanguage, disableLanguage } from './config';
+export { runConfigInit } from './config-init';
 export { showHelp, showStatus } from './help';
+export { runHealth } from './health';
+export { runVersion } from './version';
+export { parseBenchmarkArgs, runBenchmark, formatBenchmark } from './benchmark';
+export { parseServeArgs, runServe } from './serve';
+export { runMcp } from './mcp';
+export { parseHistoryArgs, runHistory } from './history';
 export { handleUserCommand } from './user-command';
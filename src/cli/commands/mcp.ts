@@ -0,0 +1,138 @@
+/**
+ * MCP command - checks as a Model Context Protocol tool
+ *
+ * `claude-lsp-cli mcp` speaks MCP over stdio (newline-delimited JSON-RPC)
+ * so Claude Desktop and other MCP clients can ask what errors a file has.
+ * Add it to claude_desktop_config.json:
+ *
+ *   { "mcpServers": { "claude-lsp": { "command": "claude-lsp-cli", "args": ["mcp"] } } }
+ */
+
+import { createInterface } from 'readline';
+import { dirname, resolve } from 'path';
+import { isSupportedLanguage } from '../../language-extensions';
+import { VERSION } from '../../version';
+import { jsonFormatter } from '../formatters/json';
+import { loadCheckConfig } from '../utils/check-config';
+import { collectResults } from './check';
+
+// Used when the client doesn't say which protocol version it speaks
+const PROTOCOL_VERSION = '2024-11-05';
+
+interface JsonRpcMessage {
+  jsonrpc?: string;
+  id?: string | number | null;
+  method?: string;
+  params?: Record<string, unknown>;
+}
+
+interface ToolResult {
+  content: Array<{ type: 'text'; text: string }>;
+  isError?: boolean;
+}
+
+export const MCP_TOOLS = [
+  {
+    name: 'analyze_file',
+    description:
+      'Check a source file with its language checker (tsc, pyright, go vet, ...) and list ' +
+      'its diagnostics as JSON: file, line, col, severity, code, message, tool.',
+    inputSchema: {
+      type: 'object',
+      properties: {
+        path: { type: 'string', description: 'Path of the file, absolute or relative to cwd' },
+        language: {
+          type: 'string',
+          description: 'Check as this language instead of the one its extension implies',
+        },
+      },
+      required: ['path'],
+    },
+  },
+];
+
+function toolError(text: string): ToolResult {
+  return { content: [{ type: 'text', text }], isError: true };
+}
+
+/**
+ * analyze_file: the same results `check --format json` prints, with the
+ * file's project config applied
+ */
+export async function analyzeFile(args: Record<string, unknown>): Promise<ToolResult> {
+  const { path, language } = args;
+  if (typeof path !== 'string' || !path) {
+    return toolError('"path" is required');
+  }
+  const checkLanguage =
+    typeof language === 'string' && isSupportedLanguage(language) ? language : undefined;
+  if (language !== undefined && !checkLanguage) {
+    return toolError(`Unsupported language: ${String(language)}`);
+  }
+
+  const absolutePath = resolve(path);
+  const options = loadCheckConfig(dirname(absolutePath), () => {});
+  const results = await collectResults([absolutePath], { ...options, language: checkLanguage });
+  if (results.length === 0) {
+    return toolError(`${path} was not checked: file not found, excluded or language disabled`);
+  }
+  return { content: [{ type: 'text', text: jsonFormatter.format(results) }] };
+}
+
+/**
+ * Answer one JSON-RPC message; notifications get null
+ */
+export async function handleMcpMessage(message: JsonRpcMessage): Promise<object | null> {
+  const { id, method, params = {} } = message;
+  if (id === undefined || id === null) {
+    return null;
+  }
+
+  const reply = (result: object): object => ({ jsonrpc: '2.0', id, result });
+  switch (method) {
+    case 'initialize':
+      return reply({
+        protocolVersion:
+          typeof params.protocolVersion === 'string' ? params.protocolVersion : PROTOCOL_VERSION,
+        capabilities: { tools: {} },
+        serverInfo: { name: 'claude-lsp-cli', version: VERSION },
+      });
+    case 'ping':
+      return reply({});
+    case 'tools/list':
+      return reply({ tools: MCP_TOOLS });
+    case 'tools/call': {
+      const args = (params.arguments ?? {}) as Record<string, unknown>;
+      if (params.name !== 'analyze_file') {
+        return reply(toolError(`Unknown tool: ${String(params.name)}`));
+      }
+      return reply(await analyzeFile(args));
+    }
+    default:
+      return { jsonrpc: '2.0', id, error: { code: -32601, message: `Unknown method: ${method}` } };
+  }
+}
+
+/**
+ * Serve MCP on stdin/stdout until stdin closes. Logs must go to stderr.
+ */
+export async function runMcp(): Promise<void> {
+  const lines = createInterface({ input: process.stdin });
+  for await (const line of lines) {
+    if (!line.trim()) {
+      continue;
+    }
+    let message: JsonRpcMessage;
+    try {
+      message = JSON.parse(line) as JsonRpcMessage;
+    } catch {
+      const error = { code: -32700, message: 'Parse error' };
+      process.stdout.write(JSON.stringify({ jsonrpc: '2.0', id: null, error }) + '\n');
+      continue;
+    }
+    const response = await handleMcpMessage(message);
+    if (response) {
+      process.stdout.write(JSON.stringify(response) + '\n');
+    }
+  }
+}
@@ -0,0 +1,227 @@
+/**
+ * Serve command - check files over HTTP
+ *
+ * `claude-lsp-cli serve` keeps one process running for editor extensions:
+ *
+ *   POST /analyze  {"files": ["/project/src/a.ts"], "options": {"minSeverity": "error"}}
+ *                  → the same array `check --format json` prints
+ *   GET  /health   → {"status": "ok", ...}
+ *   GET  /metrics  → Prometheus text format
+ *   POST /config/reload  {"path": "/project/src/a.ts"}
+ *                  → {"options": {...}, "warnings": [...]} as the next /analyze sees them
+ *
+ * `options` takes the same keys as .claude-lsp.json, except toolPaths and
+ * hooks (they pick commands to run), and wins over the config files of the
+ * first file's project. Config files are read for every request, so edits
+ * apply without a restart and in-flight requests finish with the config
+ * they started with. /analyze only accepts application/json bodies, which a
+ * web page can't send cross-origin without a CORS preflight. With
+ * --server-token every request needs `Authorization: Bearer <token>`.
+ */
+
+import { timingSafeEqual } from 'crypto';
+import { dirname, resolve } from 'path';
+import { performance } from 'perf_hooks';
+import { URL } from 'url';
+import { VERSION } from '../../version';
+import { jsonFormatter } from '../formatters/json';
+import { configToCheckOptions, loadCheckConfig } from '../utils/check-config';
+import { MetricsCollector } from '../utils/metrics';
+import { collectResults } from './check';
+
+export const DEFAULT_SERVE_PORT = 7777;
+
+// Config keys that name executables or shell commands, so only config files may set them
+const UNSAFE_REQUEST_OPTIONS = ['toolPaths', 'hooks'];
+
+export interface ServeOptions {
+  port: number;
+  /** Loopback by default: the API runs checkers on any path it's sent */
+  host: string;
+  token?: string;
+}
+
+export interface ParsedServeArgs {
+  options: ServeOptions;
+  error?: string;
+}
+
+export function parseServeArgs(
+  args: string[],
+  env: Record<string, string | undefined> = process.env
+): ParsedServeArgs {
+  const options: ServeOptions = {
+    port: DEFAULT_SERVE_PORT,
+    host: '127.0.0.1',
+    token: env.CLAUDE_LSP_SERVER_TOKEN || undefined,
+  };
+
+  for (let i = 0; i < args.length; i++) {
+    const arg = args[i] ?? '';
+    // Split on the first = only: tokens are often base64 and end in =
+    const equals = arg.indexOf('=');
+    const flag = equals === -1 ? arg : arg.slice(0, equals);
+    const inline = equals === -1 ? undefined : arg.slice(equals + 1);
+    const value = inline ?? args[++i];
+    if (flag === '--port' && value && /^\d+$/.test(value) && parseInt(value, 10) <= 65535) {
+      options.port = parseInt(value, 10);
+    } else if (flag === '--host' && value) {
+      options.host = value;
+    } else if (flag === '--server-token' && value) {
+      options.token = value;
+    } else {
+      return { options, error: `Invalid option: ${arg}${inline ? '' : ` ${value ?? ''}`}` };
+    }
+  }
+  return { options };
+}
+
+function json(body: unknown, status = 200): Response {
+  return new Response(JSON.stringify(body, null, 2), {
+    status,
+    headers: { 'Content-Type': 'application/json' },
+  });
+}
+
+function isAuthorized(request: Request, token: string | undefined): boolean {
+  if (!token) {
+    return true;
+  }
+  const expected = Buffer.from(`Bearer ${token}`);
+  const actual = Buffer.from(request.headers.get('Authorization') ?? '');
+  return actual.length === expected.length && timingSafeEqual(actual, expected);
+}
+
+async function analyze(request: Request, metrics: MetricsCollector): Promise<Response> {
+  const startedAt = performance.now();
+  const contentType = request.headers.get('Content-Type')?.split(';')[0]?.trim().toLowerCase();
+  if (contentType !== 'application/json') {
+    return json({ error: 'Content-Type must be application/json' }, 415);
+  }
+  let body: { files?: unknown; options?: unknown };
+  try {
+    body = (await request.json()) as typeof body;
+  } catch {
+    return json({ error: 'Request body must be JSON' }, 400);
+  }
+
+  const { files, options = {} } = body ?? {};
+  if (!Array.isArray(files) || files.length === 0 || !files.every((f) => typeof f === 'string')) {
+    return json({ error: '"files" must be a non-empty list of paths' }, 400);
+  }
+  if (!options || typeof options !== 'object' || Array.isArray(options)) {
+    return json({ error: '"options" must be an object of config keys' }, 400);
+  }
+  const unsafe = UNSAFE_REQUEST_OPTIONS.filter((key) => key in options);
+  if (unsafe.length > 0) {
+    return json({ error: `"options" cannot set ${unsafe.join(', ')}; use a config file` }, 400);
+  }
+
+  const warnings: string[] = [];
+  const requestOptions = configToCheckOptions(
+    options as Record<string, unknown>,
+    'request',
+    (message) => warnings.push(message)
+  );
+  if (warnings.length > 0) {
+    return json({ error: warnings.join('\n') }, 400);
+  }
+
+  const paths = files.map((file: string) => resolve(file));
+  const configOptions = loadCheckConfig(dirname(paths[0] ?? ''), () => {});
+  const results = await collectResults(paths, { ...configOptions, ...requestOptions });
+  metrics.recordAnalysis(results, (performance.now() - startedAt) / 1000);
+
+  return new Response(jsonFormatter.format(results), {
+    headers: { 'Content-Type': 'application/json' },
+  });
+}
+
+/**
+ * Read the config files for a path (default: the server's cwd) the way
+ * /analyze does, so operators can verify an edit took effect
+ */
+async function reloadConfig(request: Request): Promise<Response> {
+  let path: unknown;
+  try {
+    const text = await request.text();
+    path = text.trim() ? (JSON.parse(text) as { path?: unknown })?.path : undefined;
+  } catch {
+    return json({ error: 'Request body must be JSON' }, 400);
+  }
+  if (path !== undefined && typeof path !== 'string') {
+    return json({ error: '"path" must be a file path' }, 400);
+  }
+
+  const warnings: string[] = [];
+  const cwd = path ? dirname(resolve(path)) : process.cwd();
+  const options = loadCheckConfig(cwd, (message) => warnings.push(message));
+  return json({ options, warnings });
+}
+
+/**
+ * Request handler for the server, separate from Bun.serve so it can be tested directly
+ */
+export function createServeHandler(
+  token: string | undefined,
+  stats: { startedAt: number; inFlight: number } = { startedAt: Date.now(), inFlight: 0 },
+  metrics: MetricsCollector = new MetricsCollector()
+): (_request: Request) => Promise<Response> {
+  return async (request) => {
+    if (!isAuthorized(request, token)) {
+      return json({ error: 'Unauthorized' }, 401);
+    }
+
+    const { pathname } = new URL(request.url);
+    if (pathname === '/health' && request.method === 'GET') {
+      return json({
+        status: 'ok',
+        version: VERSION,
+        uptimeSeconds: Math.round((Date.now() - stats.startedAt) / 1000),
+        inFlight: stats.inFlight,
+      });
+    }
+    if (pathname === '/metrics' && request.method === 'GET') {
+      return new Response(metrics.render(), {
+        headers: { 'Content-Type': 'text/plain; version=0.0.4' },
+      });
+    }
+    if (pathname === '/config/reload' && request.method === 'POST') {
+      return reloadConfig(request);
+    }
+    if (pathname === '/analyze') {
+      if (request.method !== 'POST') {
+        return json({ error: 'Use POST /analyze' }, 405);
+      }
+      stats.inFlight++;
+      try {
+        return await analyze(request, metrics);
+      } finally {
+        stats.inFlight--;
+      }
+    }
+    return json({ error: `Not found: ${pathname}` }, 404);
+  };
+}
+
+/**
+ * Serve until SIGTERM or SIGINT, then finish in-flight requests and exit
+ */
+export async function runServe(options: ServeOptions): Promise<void> {
+  const server = Bun.serve({
+    port: options.port,
+    hostname: options.host,
+    fetch: createServeHandler(options.token),
+  });
+  console.error(`Listening on http://${server.hostname}:${server.port}`);
+
+  await new Promise<void>((resolveShutdown) => {
+    const shutdown = (): void => {
+      console.error('Shutting down after in-flight requests');
+      // Without closeActiveConnections, stop waits for pending responses
+      void Promise.resolve(server.stop()).then(() => resolveShutdown());
+    };
+    process.once('SIGTERM', shutdown);
+    process.once('SIGINT', shutdown);
+  });
+}
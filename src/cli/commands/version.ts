@@ -0,0 +1,101 @@
+/**
+ * Version command
+ *
+ * `claude-lsp-cli version` prints the version with build metadata, as text
+ * or with `--format json`. Setting `"checkUpdates": true` in the global
+ * config also compares it with the latest GitHub release.
+ */
+
+import { BUILD_TIME, COMMIT, HOMEPAGE, VERSION } from '../../version';
+import { readLspConfig } from '../../utils/common';
+
+export interface VersionInfo {
+  version: string;
+  commit: string;
+  buildDate: string;
+  bun: string;
+  platform: string;
+}
+
+// Don't hold up the command on a slow network
+const UPDATE_CHECK_TIMEOUT_MS = 2000;
+
+export function versionInfo(): VersionInfo {
+  return {
+    version: VERSION,
+    commit: COMMIT,
+    buildDate: BUILD_TIME,
+    bun: Bun.version,
+    platform: `${process.platform}-${process.arch}`,
+  };
+}
+
+export function formatVersion(info: VersionInfo): string {
+  return [
+    `claude-lsp-cli ${info.version}`,
+    `  commit:   ${info.commit}`,
+    `  built:    ${info.buildDate}`,
+    `  bun:      ${info.bun}`,
+    `  platform: ${info.platform}`,
+  ].join('\n');
+}
+
+/**
+ * Whether SemVer `candidate` is newer than `current`. A leading v and
+ * pre-release or build suffixes are ignored.
+ */
+export function isNewerVersion(candidate: string, current: string): boolean {
+  const parts = (value: string): number[] =>
+    (value.replace(/^v/, '').split(/[-+]/)[0] ?? '')
+      .split('.')
+      .map((part) => parseInt(part, 10) || 0);
+  const a = parts(candidate);
+  const b = parts(current);
+  for (let i = 0; i < Math.max(a.length, b.length); i++) {
+    const diff = (a[i] ?? 0) - (b[i] ?? 0);
+    if (diff !== 0) {
+      return diff > 0;
+    }
+  }
+  return false;
+}
+
+/**
+ * Tag of the latest GitHub release, or null when it can't be fetched in time
+ */
+async function latestReleaseTag(): Promise<string | null> {
+  const repo = HOMEPAGE.replace(/^https:\/\/github\.com\//, '').replace(/#.*$/, '');
+  const controller = new AbortController();
+  const timer = setTimeout(() => controller.abort(), UPDATE_CHECK_TIMEOUT_MS);
+  try {
+    const response = await fetch(`https://api.github.com/repos/${repo}/releases/latest`, {
+      signal: controller.signal,
+      headers: { Accept: 'application/vnd.github+json' },
+    });
+    if (!response.ok) {
+      return null;
+    }
+    const release = (await response.json()) as { tag_name?: unknown };
+    return typeof release.tag_name === 'string' ? release.tag_name : null;
+  } catch {
+    return null;
+  } finally {
+    clearTimeout(timer);
+  }
+}
+
+export async function runVersion(
+  format: string = 'text',
+  log: (..._args: unknown[]) => unknown = console.log
+): Promise<void> {
+  const info = versionInfo();
+  // Start the update check right away so it overlaps with printing
+  const latest = readLspConfig().checkUpdates === true ? latestReleaseTag() : null;
+
+  log(format === 'json' ? JSON.stringify(info, null, 2) : formatVersion(info));
+
+  const tag = await latest;
+  if (tag && isNewerVersion(tag, info.version)) {
+    console.error(`A newer version is available: ${tag} (${HOMEPAGE})`);
+  }
+}
@@ -0,0 +1,117 @@
+import { existsSync, watch } from 'fs';
+import { basename, dirname, extname, join, relative, resolve } from 'path';
+import { findProjectRoot } from '../../utils/common';
+import type { CheckOptions } from '../utils/check-options';
+import { shouldColorize } from '../utils/color';
+import { timestamp } from '../utils/progress';
+import { runCheck } from './check';
+
+// Editors often emit several change events per save
+const DEBOUNCE_MS = 150;
+
+// Project files whose changes affect every source file of the language, e.g.
+// `go get` or `go mod tidy` in another terminal rewriting go.mod and go.sum.
+// The first one marks the project root.
+const BUILD_FILES: Record<string, string[]> = {
+  '.go': ['go.mod', 'go.sum'],
+};
+
+/**
+ * Build files (absolute paths) for the watched files, each with the files to
+ * re-check when it changes
+ */
+export function buildFileDependents(files: string[]): Map<string, string[]> {
+  const dependents = new Map<string, string[]>();
+  for (const file of files) {
+    const buildFiles = BUILD_FILES[extname(file)];
+    const root = findProjectRoot(file);
+    if (!buildFiles?.[0] || !existsSync(join(root, buildFiles[0]))) {
+      continue;
+    }
+    for (const name of buildFiles) {
+      const buildFile = join(root, name);
+      dependents.set(buildFile, [...(dependents.get(buildFile) ?? []), file]);
+    }
+  }
+  return dependents;
+}
+
+async function checkWithHeader(file: string, options: CheckOptions): Promise<void> {
+  process.stderr.write(`[${timestamp()}] ${relative(process.cwd(), file) || file}`);
+  // The spinner would overwrite the header line
+  await runCheck(file, { ...options, progress: false });
+  process.stderr.write('\n');
+}
+
+/**
+ * Check files one after another below a single screen clear, so the whole
+ * batch stays visible rather than just its last file
+ */
+async function checkBatch(files: string[], options: CheckOptions): Promise<void> {
+  // Clear previous results so the terminal doesn't scroll endlessly
+  if (shouldColorize(process.stderr)) {
+    process.stderr.write('\x1b[2J\x1b[H');
+  }
+  for (const file of files) {
+    await checkWithHeader(file, options);
+  }
+}
+
+/**
+ * Check files once, then re-check each file whenever it is saved, and every
+ * file of a project whenever its build files (go.mod, go.sum) change.
+ * Only resolves if there is nothing to watch.
+ */
+export async function runWatch(filePaths: string[], options: CheckOptions = {}): Promise<void> {
+  const files = filePaths.map((filePath) => resolve(filePath)).filter(existsSync);
+  if (files.length === 0) {
+    return;
+  }
+
+  await checkBatch(files, options);
+
+  // Watch parent directories rather than the files themselves: editors that
+  // save via rename replace the inode and would silently end a file watch
+  const dependents = buildFileDependents(files);
+  const filesByDir = new Map<string, Set<string>>();
+  for (const file of [...files, ...dependents.keys()]) {
+    const dir = dirname(file);
+    const names = filesByDir.get(dir) || new Set<string>();
+    names.add(basename(file));
+    filesByDir.set(dir, names);
+  }
+
+  const pending = new Map<string, ReturnType<typeof setTimeout>>();
+  let queue = Promise.resolve();
+
+  for (const [dir, names] of filesByDir) {
+    watch(dir, (_event, filename) => {
+      if (!filename || !names.has(filename.toString())) return;
+
+      const file = resolve(dir, filename.toString());
+      const timer = pending.get(file);
+      if (timer) clearTimeout(timer);
+
+      pending.set(
+        file,
+        setTimeout(() => {
+          pending.delete(file);
+          // A removed go.sum still changes what the module resolves to
+          const stale = dependents.get(file) ?? (existsSync(file) ? [file] : []);
+          if (stale.length === 0) return;
+          // Serialize checks so output from different files doesn't interleave. A failed
+          // check is reported and must not stop the checks queued after it.
+          queue = queue
+            .then(() => checkBatch(stale, options))
+            .catch((error: unknown) => {
+              const message = error instanceof Error ? error.message : String(error);
+              process.stderr.write(`\n⚠ Check failed: ${message}\n`);
+            });
+        }, DEBOUNCE_MS)
+      );
+    });
+  }
+
+  process.stderr.write(`\nWatching ${files.length} file(s) for changes. Press Ctrl+C to stop.\n`);
+  await new Promise<never>(() => {});
+}
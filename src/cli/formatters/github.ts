@@ -0,0 +1,47 @@
+/**
+ * GitHub Actions annotation formatter
+ *
+ * Emits one workflow command per diagnostic so errors show up inline in
+ * pull request diffs:
+ *   ::error file=src/index.ts,line=3,col=7,title=TS2322::Type 'string' is not...
+ * File paths are relative to the repository root, as GitHub resolves them.
+ * See https://docs.github.com/actions/using-workflows/workflow-commands-for-github-actions
+ */
+
+import type { Diagnostic } from '../../file-checker';
+import { repositoryPath } from '../utils/git-changes';
+import type { DiagnosticFormatter } from './types';
+
+const COMMANDS: Record<Diagnostic['severity'], string> = {
+  error: 'error',
+  warning: 'warning',
+  info: 'notice',
+};
+
+function escapeData(value: string): string {
+  return value.replace(/%/g, '%25').replace(/\r/g, '%0D').replace(/\n/g, '%0A');
+}
+
+function escapeProperty(value: string): string {
+  return escapeData(value).replace(/:/g, '%3A').replace(/,/g, '%2C');
+}
+
+export const githubFormatter: DiagnosticFormatter = {
+  name: 'github',
+
+  format(results) {
+    const lines = results.flatMap((result) =>
+      result.diagnostics.map((diag) => {
+        const properties = [
+          `file=${escapeProperty(diag.file || repositoryPath(result))}`,
+          `line=${diag.line}`,
+          `col=${diag.column}`,
+          ...(diag.code ? [`title=${escapeProperty(diag.code)}`] : []),
+        ];
+        return `::${COMMANDS[diag.severity]} ${properties.join(',')}::${escapeData(diag.message)}`;
+      })
+    );
+
+    return lines.join('\n');
+  },
+};
@@ -0,0 +1,200 @@
+/**
+ * HTML output formatter
+ *
+ * Produces a single self-contained HTML5 page (inline CSS, no scripts or
+ * external assets) that opens straight from disk: a donut chart of
+ * diagnostics by severity, then a collapsible section per file. With
+ * --context-lines each diagnostic shows its highlighted source snippet.
+ * Files without diagnostics are listed in a collapsed "Clean files" section.
+ */
+
+import type { Diagnostic } from '../../file-checker';
+import type { DiagnosticFormatter } from './types';
+
+const SEVERITY_COLORS: Record<Diagnostic['severity'], string> = {
+  error: '#d73a49',
+  warning: '#dbab09',
+  info: '#0366d6',
+};
+
+// Keywords shared by most supported languages; enough to make snippets readable
+const KEYWORDS = new Set(
+  (
+    'as async await break case catch class const continue def default defer else enum export ' +
+    'extends false fn for from func function go if impl import in interface let match mut new ' +
+    'nil null package pub return self static struct super switch this throw true try type use ' +
+    'val var while yield'
+  ).split(' ')
+);
+
+const TOKEN_PATTERN =
+  /(\/\/.*$|#.*$)|("(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|`[^`]*`)|\b(\d[\w.]*)\b|\b([A-Za-z_]\w*)\b/g;
+
+const STYLE = `
+  body { font: 14px/1.5 system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; }
+  h1 { font-size: 1.5rem; }
+  .summary { display: flex; align-items: center; gap: 2rem; }
+  .legend span { display: inline-block; width: 0.8em; height: 0.8em; margin-right: 0.4em; }
+  details { border: 1px solid #e1e4e8; border-radius: 6px; margin: 0.5rem 0; padding: 0 1rem; }
+  summary { cursor: pointer; font-weight: 600; }
+  ul { list-style: none; padding: 0; }
+  li { margin: 0.5rem 0; }
+  .error { color: ${SEVERITY_COLORS.error}; }
+  .warning { color: ${SEVERITY_COLORS.warning}; }
+  .info { color: ${SEVERITY_COLORS.info}; }
+  pre { background: #f6f8fa; padding: 0.5rem; overflow-x: auto; }
+  .kw { color: #d73a49; }
+  .str { color: #032f62; }
+  .num { color: #005cc5; }
+  .com { color: #6a737d; }
+`;
+
+function escapeHtml(text: string): string {
+  return text
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;');
+}
+
+/**
+ * Escape a source line and wrap comments, strings, numbers and keywords in
+ * spans. Token-by-token, so a keyword inside a string stays a string.
+ */
+export function highlightLine(line: string): string {
+  let html = '';
+  let last = 0;
+  for (const match of line.matchAll(TOKEN_PATTERN)) {
+    const [token, comment, string, number, word] = match;
+    const index = match.index ?? 0;
+    html += escapeHtml(line.slice(last, index));
+    last = index + token.length;
+    let kind: string | null = null;
+    if (comment) {
+      kind = 'com';
+    } else if (string) {
+      kind = 'str';
+    } else if (number) {
+      kind = 'num';
+    } else if (word && KEYWORDS.has(word)) {
+      kind = 'kw';
+    }
+    html += kind ? `<span class="${kind}">${escapeHtml(token)}</span>` : escapeHtml(token);
+  }
+  return html + escapeHtml(line.slice(last));
+}
+
+/**
+ * The snippet's numbered lines with only the source part highlighted
+ */
+function renderSnippet(snippet: string): string {
+  // Snippets are fenced Markdown code blocks; the fence lines aren't source
+  const lines = snippet.split('\n').filter((line) => !line.startsWith('```'));
+  const rendered = lines.map((line) => {
+    const gutter = line.match(/^[> ] *\d+ \| /)?.[0] ?? '';
+    return escapeHtml(gutter) + highlightLine(line.slice(gutter.length));
+  });
+  return `<pre><code>${rendered.join('\n')}</code></pre>`;
+}
+
+/**
+ * SVG donut with one arc per severity; the circle's circumference is 100,
+ * so arc lengths are percentages
+ */
+function renderDonut(counts: Record<Diagnostic['severity'], number>, total: number): string {
+  const arcs: string[] = [];
+  let offset = 0;
+  for (const severity of ['error', 'warning', 'info'] as const) {
+    const share = (counts[severity] / total) * 100;
+    if (share > 0) {
+      arcs.push(
+        `<circle r="15.9155" cx="21" cy="21" fill="none" stroke="${SEVERITY_COLORS[severity]}" ` +
+          `stroke-width="6" stroke-dasharray="${share} ${100 - share}" ` +
+          `stroke-dashoffset="${25 - offset}"/>`
+      );
+    }
+    offset += share;
+  }
+  return [
+    '<svg width="160" height="160" viewBox="0 0 42 42" role="img" aria-label="By severity">',
+    ...arcs,
+    `<text x="21" y="23" text-anchor="middle" font-size="6">${total}</text>`,
+    '</svg>',
+  ].join('\n');
+}
+
+function renderDiagnostic(diag: Diagnostic): string {
+  const code = diag.code ? ` ${escapeHtml(diag.code)}` : '';
+  const heading =
+    `<span class="${diag.severity}">${diag.severity}</span> ` +
+    `${diag.line}:${diag.column}${code}: ${escapeHtml(diag.message)}`;
+  const snippet = diag.snippet ? renderSnippet(diag.snippet) : '';
+  return `<li>${heading}${snippet}</li>`;
+}
+
+export const htmlFormatter: DiagnosticFormatter = {
+  name: 'html',
+
+  format(results) {
+    const withDiagnostics = results.filter((result) => result.diagnostics.length > 0);
+    const clean = results.filter((result) => result.diagnostics.length === 0);
+    const counts = { error: 0, warning: 0, info: 0 };
+    for (const diag of withDiagnostics.flatMap((result) => result.diagnostics)) {
+      counts[diag.severity]++;
+    }
+    const total = counts.error + counts.warning + counts.info;
+
+    const body: string[] = ['<h1>Diagnostics report</h1>'];
+    if (total === 0) {
+      body.push('<p>No issues found.</p>');
+    } else {
+      const legend = (['error', 'warning', 'info'] as const).map(
+        (severity) =>
+          `<div><span style="background:${SEVERITY_COLORS[severity]}"></span>` +
+          `${counts[severity]} ${severity}</div>`
+      );
+      body.push(
+        '<div class="summary">',
+        renderDonut(counts, total),
+        `<div class="legend">${legend.join('')}</div>`,
+        '</div>'
+      );
+    }
+
+    for (const result of withDiagnostics) {
+      body.push(
+        '<details open>',
+        `<summary>${escapeHtml(result.file)} (${result.diagnostics.length})</summary>`,
+        '<ul>',
+        ...result.diagnostics.map(renderDiagnostic),
+        '</ul>',
+        '</details>'
+      );
+    }
+
+    if (clean.length > 0) {
+      body.push(
+        '<details>',
+        `<summary>Clean files (${clean.length})</summary>`,
+        '<ul>',
+        ...clean.map((result) => `<li>${escapeHtml(result.file)}</li>`),
+        '</ul>',
+        '</details>'
+      );
+    }
+
+    return [
+      '<!DOCTYPE html>',
+      '<html lang="en">',
+      '<head>',
+      '<meta charset="utf-8">',
+      '<title>Diagnostics report</title>',
+      `<style>${STYLE}</style>`,
+      '</head>',
+      '<body>',
+      ...body,
+      '</body>',
+      '</html>',
+    ].join('\n');
+  },
+};
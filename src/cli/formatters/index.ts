@@ -0,0 +1,32 @@
+/**
+ * Output formatter registry
+ *
+ * The default `text` format is the shell integration summary written to
+ * stderr; every other format renders a document to stdout.
+ */
+
+import type { DiagnosticFormatter } from './types';
+import { jsonFormatter } from './json';
+import { githubFormatter } from './github';
+import { markdownFormatter } from './markdown';
+import { sarifFormatter } from './sarif';
+import { junitFormatter } from './junit';
+import { htmlFormatter } from './html';
+
+export type { DiagnosticFormatter } from './types';
+
+export const FORMATTERS = new Map<string, DiagnosticFormatter>([
+  [jsonFormatter.name, jsonFormatter],
+  [githubFormatter.name, githubFormatter],
+  [markdownFormatter.name, markdownFormatter],
+  [sarifFormatter.name, sarifFormatter],
+  [junitFormatter.name, junitFormatter],
+  [htmlFormatter.name, htmlFormatter],
+]);
+
+// All values accepted by --format
+export const OUTPUT_FORMATS = ['text', ...FORMATTERS.keys()];
+
+export function getFormatter(name: string): DiagnosticFormatter | null {
+  return FORMATTERS.get(name) || null;
+}
@@ -0,0 +1,31 @@
+/**
+ * JSON output formatter
+ *
+ * Emits a single top-level array of diagnostics across all checked files,
+ * so consumers can filter with e.g. `jq '.[] | select(.severity == "error")'`
+ * without special-casing single vs multiple files.
+ */
+
+import type { DiagnosticFormatter } from './types';
+
+export const jsonFormatter: DiagnosticFormatter = {
+  name: 'json',
+
+  format(results) {
+    const rows = results.flatMap((result) =>
+      result.diagnostics.map((diag) => ({
+        file: diag.file || result.file,
+        line: diag.line,
+        col: diag.column,
+        severity: diag.severity,
+        code: diag.code ?? null,
+        message: diag.message,
+        tool: result.tool,
+        language: result.language,
+        impact: diag.impact,
+      }))
+    );
+
+    return JSON.stringify(rows, null, 2);
+  },
+};
@@ -0,0 +1,57 @@
+/**
+ * JUnit XML output formatter
+ *
+ * Each checked file is a <testsuite>. Errors become failing test cases;
+ * warnings and infos are passing test cases whose message is kept in
+ * <system-out>. Files without diagnostics get a single passing case,
+ * since some consumers reject empty suites.
+ */
+
+import type { DiagnosticFormatter } from './types';
+
+function escapeXml(text: string): string {
+  return text
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;')
+    .replace(/'/g, '&apos;');
+}
+
+export const junitFormatter: DiagnosticFormatter = {
+  name: 'junit',
+
+  format(results) {
+    const lines = ['<?xml version="1.0" encoding="UTF-8"?>', '<testsuites>'];
+
+    for (const result of results) {
+      const file = escapeXml(result.file);
+      const errors = result.diagnostics.filter((diag) => diag.severity === 'error').length;
+      const tests = Math.max(result.diagnostics.length, 1);
+      lines.push(`  <testsuite name="${file}" tests="${tests}" failures="${errors}">`);
+
+      if (result.diagnostics.length === 0) {
+        lines.push(`    <testcase name="${escapeXml(result.tool)}" classname="${file}"/>`);
+      }
+
+      for (const diag of result.diagnostics) {
+        const name = escapeXml(`${diag.code ?? result.tool}: ${diag.line}:${diag.column}`);
+        const message = escapeXml(diag.message);
+        lines.push(`    <testcase name="${name}" classname="${file}">`);
+        if (diag.severity === 'error') {
+          lines.push(
+            `      <failure message="${message}" type="${diag.severity}">${message}</failure>`
+          );
+        } else {
+          lines.push(`      <system-out>${diag.severity}: ${message}</system-out>`);
+        }
+        lines.push('    </testcase>');
+      }
+
+      lines.push('  </testsuite>');
+    }
+
+    lines.push('</testsuites>');
+    return lines.join('\n');
+  },
+};
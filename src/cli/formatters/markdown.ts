@@ -0,0 +1,72 @@
+/**
+ * Markdown output formatter
+ *
+ * Produces a GitHub Flavored Markdown report for pasting into issues or
+ * docs: a summary table of errors/warnings per file, then one section per
+ * file. With --context-lines each diagnostic becomes a collapsible
+ * <details> block holding its source snippet.
+ */
+
+import type { Diagnostic } from '../../file-checker';
+import type { DiagnosticFormatter } from './types';
+
+function escapeHtml(text: string): string {
+  return text.replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;');
+}
+
+function escapeTableCell(text: string): string {
+  return escapeHtml(text).replace(/\|/g, '\\|');
+}
+
+function describe(diag: Diagnostic): string {
+  const icon = diag.severity === 'error' ? '✗' : '⚠';
+  const code = diag.code ? ` ${diag.code}` : '';
+  return `${icon} ${diag.line}:${diag.column}${code}: ${escapeHtml(diag.message)}`;
+}
+
+export const markdownFormatter: DiagnosticFormatter = {
+  name: 'markdown',
+
+  format(results) {
+    const withDiagnostics = results.filter((result) => result.diagnostics.length > 0);
+    const lines: string[] = ['## Summary', ''];
+
+    if (withDiagnostics.length === 0) {
+      lines.push('No issues found.');
+      return lines.join('\n');
+    }
+
+    // Non-errors count as warnings, matching the text output summary
+    let totalErrors = 0;
+    let totalWarnings = 0;
+    lines.push('| File | Errors | Warnings |', '| --- | ---: | ---: |');
+    for (const result of withDiagnostics) {
+      const errors = result.diagnostics.filter((diag) => diag.severity === 'error').length;
+      const warnings = result.diagnostics.length - errors;
+      totalErrors += errors;
+      totalWarnings += warnings;
+      lines.push(`| ${escapeTableCell(result.file)} | ${errors} | ${warnings} |`);
+    }
+    lines.push(`| **Total** | ${totalErrors} | ${totalWarnings} |`);
+
+    for (const result of withDiagnostics) {
+      lines.push('', `### ${escapeHtml(result.file)}`, '');
+      for (const diag of result.diagnostics) {
+        if (diag.snippet) {
+          lines.push(
+            '<details>',
+            `<summary>${describe(diag)}</summary>`,
+            '',
+            diag.snippet,
+            '',
+            '</details>'
+          );
+        } else {
+          lines.push(`- ${describe(diag)}`);
+        }
+      }
+    }
+
+    return lines.join('\n');
+  },
+};
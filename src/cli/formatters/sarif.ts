@@ -0,0 +1,93 @@
+/**
+ * SARIF 2.1.0 output formatter
+ *
+ * Produces a single-run SARIF log for GitHub Code Scanning and other
+ * SARIF consumers. Diagnostic codes become tool.driver.rules; diagnostics
+ * without a code are reported without a ruleId. Artifact URIs are relative
+ * to the repository root (%SRCROOT%), so code scanning can map them to files.
+ * See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+ */
+
+import { pathToFileURL } from 'url';
+import type { Diagnostic } from '../../file-checker';
+import { repositoryPath, repositoryRoot } from '../utils/git-changes';
+import { HOMEPAGE, VERSION } from '../../version';
+import type { DiagnosticFormatter } from './types';
+
+const SRCROOT = '%SRCROOT%';
+
+const LEVELS: Record<Diagnostic['severity'], string> = {
+  error: 'error',
+  warning: 'warning',
+  info: 'note',
+};
+
+export const sarifFormatter: DiagnosticFormatter = {
+  name: 'sarif',
+
+  format(results) {
+    const ruleIndexes = new Map<string, number>();
+    const rules: Array<{ id: string; properties: { tool: string } }> = [];
+
+    const sarifResults = results.flatMap((result) =>
+      result.diagnostics.map((diag) => {
+        let ruleIndex: number | undefined;
+        if (diag.code) {
+          ruleIndex = ruleIndexes.get(diag.code);
+          if (ruleIndex === undefined) {
+            ruleIndex = rules.length;
+            ruleIndexes.set(diag.code, ruleIndex);
+            rules.push({ id: diag.code, properties: { tool: result.tool } });
+          }
+        }
+
+        return {
+          ...(diag.code ? { ruleId: diag.code, ruleIndex } : {}),
+          level: LEVELS[diag.severity],
+          message: { text: diag.message },
+          locations: [
+            {
+              physicalLocation: {
+                artifactLocation: {
+                  uri: (diag.file || repositoryPath(result)).replace(/\\/g, '/'),
+                  uriBaseId: SRCROOT,
+                },
+                region: {
+                  startLine: Math.max(1, diag.line),
+                  startColumn: Math.max(1, diag.column),
+                },
+              },
+            },
+          ],
+        };
+      })
+    );
+
+    // The root's own URI is optional in SARIF; consumers like code scanning know their checkout
+    const sourcePath = results.find((result) => result.sourcePath)?.sourcePath;
+    const root = sourcePath ? repositoryRoot(sourcePath) : null;
+
+    const log = {
+      $schema: 'https://json.schemastore.org/sarif-2.1.0.json',
+      version: '2.1.0',
+      runs: [
+        {
+          tool: {
+            driver: {
+              name: 'claude-lsp-cli',
+              version: VERSION,
+              informationUri: HOMEPAGE,
+              rules,
+            },
+          },
+          ...(root
+            ? { originalUriBaseIds: { [SRCROOT]: { uri: pathToFileURL(`${root}/`).href } } }
+            : {}),
+          results: sarifResults,
+        },
+      ],
+    };
+
+    return JSON.stringify(log, null, 2);
+  },
+};
@@ -0,0 +1,11 @@
+import type { FileCheckResult } from '../../file-checker';
+
+/**
+ * A formatter renders the results of a check run as one complete document
+ */
+export interface DiagnosticFormatter {
+  /** Format name as accepted by --format */
+  name: string;
+  /** Render all results (one entry per checked file) */
+  format(_results: FileCheckResult[]): string;
+}
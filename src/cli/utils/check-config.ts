@@ -0,0 +1,237 @@
+/**
+ * Check command configuration
+ *
+ * Check options can be stored in config files so they don't need to be
+ * repeated on every run. Values are merged from (lowest to highest priority):
+ *   1. <project root>/.claude-lsp.json
+ *   2. Global config (--config, $CLAUDE_LSP_CONFIG, or ~/.claude/lsp-config.json)
+ *   3. Command line flags
+ */
+
+import { existsSync, readFileSync } from 'fs';
+import { join } from 'path';
+import { findProjectRoot, getConfigPath } from '../../utils/common';
+import { OUTPUT_FORMATS } from '../formatters';
+import type { CheckHooks } from './check-hooks';
+import { parseDuration, parseSize, type CheckOptions } from './check-options';
+import { parseCheckerSeverity, parseSeverityLevel } from './diagnostic-filters';
+
+export const PROJECT_CONFIG_FILE = '.claude-lsp.json';
+
+type Warn = (_message: string) => void;
+
+/**
+ * Read a JSON config file, warning (not failing) when it can't be parsed
+ */
+function readConfigFile(path: string, warn: Warn): Record<string, unknown> {
+  if (!existsSync(path)) {
+    return {};
+  }
+
+  try {
+    const parsed = JSON.parse(readFileSync(path, 'utf8'));
+    if (parsed && typeof parsed === 'object' && !Array.isArray(parsed)) {
+      return parsed as Record<string, unknown>;
+    }
+    warn(`⚠ Ignoring config ${path}: expected a JSON object`);
+  } catch (error) {
+    warn(`⚠ Ignoring config ${path}: ${error instanceof Error ? error.message : error}`);
+  }
+  return {};
+}
+
+function isStringList(value: unknown): value is string[] {
+  return Array.isArray(value) && value.every((item) => typeof item === 'string');
+}
+
+/**
+ * Validate the hooks key: { preCheck?: string[], postCheck?: string[], timeout?: "60s" }
+ */
+function parseHooks(value: unknown): CheckHooks | null {
+  if (!value || typeof value !== 'object' || Array.isArray(value)) {
+    return null;
+  }
+  const { preCheck, postCheck, timeout, ...unknown } = value as Record<string, unknown>;
+  if (
+    Object.keys(unknown).length > 0 ||
+    (preCheck !== undefined && !isStringList(preCheck)) ||
+    (postCheck !== undefined && !isStringList(postCheck))
+  ) {
+    return null;
+  }
+  const hooks: CheckHooks = {};
+  if (preCheck) hooks.preCheck = preCheck;
+  if (postCheck) hooks.postCheck = postCheck;
+  if (timeout !== undefined) {
+    const ms = parseDuration(String(timeout));
+    if (ms === null) {
+      return null;
+    }
+    hooks.timeoutMs = ms;
+  }
+  return hooks;
+}
+
+/**
+ * Validate the severityOverrides key: { "<code>": "error" | "warning" | "info" | "hint" }
+ */
+function parseSeverityOverrides(value: unknown): CheckOptions['severityOverrides'] | null {
+  if (!value || typeof value !== 'object' || Array.isArray(value)) {
+    return null;
+  }
+  const overrides: NonNullable<CheckOptions['severityOverrides']> = {};
+  for (const [code, severity] of Object.entries(value)) {
+    const parsed = typeof severity === 'string' ? parseCheckerSeverity(severity) : null;
+    if (!parsed) {
+      return null;
+    }
+    overrides[code] = parsed;
+  }
+  return overrides;
+}
+
+/**
+ * Convert raw config values into check options.
+ * Unknown keys only produce a warning so older configs keep working.
+ */
+export function configToCheckOptions(
+  config: Record<string, unknown>,
+  source: string,
+  warn: Warn
+): CheckOptions {
+  const options: CheckOptions = {};
+
+  for (const [key, value] of Object.entries(config)) {
+    // Language enable/disable keys are handled by isLanguageDisabled,
+    // checkUpdates by the version command
+    if (key === 'disable' || /^disable[A-Z]\w*$/.test(key) || key === 'checkUpdates') {
+      continue;
+    }
+
+    switch (key) {
+      case 'format':
+        if (typeof value === 'string' && OUTPUT_FORMATS.includes(value)) {
+          options.format = value;
+        } else {
+          warn(`⚠ Invalid "format" in ${source}: ${JSON.stringify(value)}`);
+        }
+        break;
+      case 'minSeverity': {
+        const level = parseSeverityLevel(String(value));
+        if (level !== null) {
+          options.minSeverity = level;
+        } else {
+          warn(`⚠ Invalid "minSeverity" in ${source}: ${JSON.stringify(value)}`);
+        }
+        break;
+      }
+      case 'maxDiagnostics':
+        if (typeof value === 'number' && Number.isInteger(value) && value > 0) {
+          options.maxDiagnostics = value;
+        } else {
+          warn(`⚠ Invalid "maxDiagnostics" in ${source}: ${JSON.stringify(value)}`);
+        }
+        break;
+      case 'contextLines':
+        if (typeof value === 'number' && Number.isInteger(value) && value >= 0) {
+          options.contextLines = value;
+        } else {
+          warn(`⚠ Invalid "contextLines" in ${source}: ${JSON.stringify(value)}`);
+        }
+        break;
+      case 'timeout': {
+        const ms = parseDuration(String(value));
+        if (ms !== null) {
+          options.timeoutMs = ms;
+        } else {
+          warn(`⚠ Invalid "timeout" in ${source}: ${JSON.stringify(value)}`);
+        }
+        break;
+      }
+      case 'maxFileSize': {
+        const bytes = parseSize(String(value));
+        if (bytes !== null) {
+          options.maxFileSize = bytes;
+        } else {
+          warn(`⚠ Invalid "maxFileSize" in ${source}: ${JSON.stringify(value)}`);
+        }
+        break;
+      }
+      case 'exclude':
+        if (Array.isArray(value) && value.every((pattern) => typeof pattern === 'string')) {
+          options.exclude = value;
+        } else {
+          warn(`⚠ Invalid "exclude" in ${source}: expected a list of glob patterns`);
+        }
+        break;
+      case 'excludeCodes':
+        if (isStringList(value)) {
+          options.excludeCodes = value;
+        } else {
+          warn(`⚠ Invalid "excludeCodes" in ${source}: expected a list of diagnostic codes`);
+        }
+        break;
+      case 'toolPaths':
+        if (
+          value &&
+          typeof value === 'object' &&
+          !Array.isArray(value) &&
+          Object.values(value).every((path) => typeof path === 'string')
+        ) {
+          options.toolPaths = value as Record<string, string>;
+        } else {
+          warn(`⚠ Invalid "toolPaths" in ${source}: expected an object of tool paths`);
+        }
+        break;
+      case 'hooks': {
+        const hooks = parseHooks(value);
+        if (hooks) {
+          options.hooks = hooks;
+        } else {
+          warn(`⚠ Invalid "hooks" in ${source}: expected preCheck/postCheck command lists`);
+        }
+        break;
+      }
+      case 'severityOverrides': {
+        const overrides = parseSeverityOverrides(value);
+        if (overrides) {
+          options.severityOverrides = overrides;
+        } else {
+          warn(`⚠ Invalid "severityOverrides" in ${source}: expected severities by code`);
+        }
+        break;
+      }
+      case 'concurrency':
+        if (typeof value === 'number' && Number.isInteger(value) && value > 0) {
+          options.concurrency = value;
+        } else {
+          warn(`⚠ Invalid "concurrency" in ${source}: ${JSON.stringify(value)}`);
+        }
+        break;
+      default:
+        warn(`⚠ Unknown config key "${key}" in ${source}, ignoring`);
+    }
+  }
+
+  return options;
+}
+
+/**
+ * Load check options from the project and global config files
+ */
+export function loadCheckConfig(
+  cwd: string = process.cwd(),
+  warn: Warn = (message) => console.error(message)
+): CheckOptions {
+  const globalPath = getConfigPath();
+  if (process.env.CLAUDE_LSP_CONFIG && !existsSync(globalPath)) {
+    warn(`⚠ Config file not found: ${globalPath}`);
+  }
+
+  const projectPath = join(findProjectRoot(join(cwd, PROJECT_CONFIG_FILE)), PROJECT_CONFIG_FILE);
+
+  return {
+    ...configToCheckOptions(readConfigFile(projectPath, warn), projectPath, warn),
+    ...configToCheckOptions(readConfigFile(globalPath, warn), globalPath, warn),
+  };
+}
@@ -0,0 +1,84 @@
+/**
+ * Shell commands run around a check, configured under "hooks" in the config
+ * files (e.g. `go generate ./...` before checking, a notification after).
+ * Not to be confused with the Claude Code hook events handled by `hook`.
+ */
+
+import type { FileCheckResult } from '../../file-checker';
+import { runCommand } from '../../utils/common';
+
+export const DEFAULT_HOOK_TIMEOUT_MS = 60000;
+
+export interface CheckHooks {
+  preCheck?: string[];
+  postCheck?: string[];
+  timeoutMs?: number;
+}
+
+export type ReportListener = (_results: FileCheckResult[]) => void;
+
+/**
+ * Run commands one after another through sh, stopping at the first that
+ * fails. Returns a description of the failure, or null if all succeeded.
+ */
+export async function runHookCommands(
+  commands: string[],
+  env: Record<string, string>,
+  timeoutMs: number = DEFAULT_HOOK_TIMEOUT_MS
+): Promise<string | null> {
+  for (const command of commands) {
+    const { stderr, timedOut, exitCode } = await runCommand(
+      ['sh', '-c', command],
+      env,
+      process.cwd(),
+      timeoutMs
+    );
+    if (timedOut) {
+      return `"${command}" timed out after ${timeoutMs / 1000}s`;
+    }
+    // runCommand reports spawn failures without an exit code
+    if (exitCode !== 0) {
+      const output = stderr.trim() ? `\n${stderr.trim()}` : '';
+      return `"${command}" exited with ${exitCode ?? 'an error'}${output}`;
+    }
+  }
+  return null;
+}
+
+/**
+ * Run the pre-check hooks, the check itself and then the post-check hooks.
+ * A failing pre-check hook aborts the check and counts as a failure; a
+ * failing post-check hook only prints a warning.
+ */
+export async function withCheckHooks(
+  hooks: CheckHooks | undefined,
+  fileCount: number,
+  check: (_onReport: ReportListener) => Promise<boolean>
+): Promise<boolean> {
+  const env = { CLAUDE_LSP_FILE_COUNT: String(fileCount) };
+
+  const preFailure = await runHookCommands(hooks?.preCheck ?? [], env, hooks?.timeoutMs);
+  if (preFailure) {
+    process.stderr.write(`preCheck hook failed, not checking: ${preFailure}\n`);
+    return true;
+  }
+
+  let diagnosticCount = 0;
+  const hasErrors = await check((results) => {
+    diagnosticCount += results.reduce((count, result) => count + result.diagnostics.length, 0);
+  });
+
+  const postFailure = await runHookCommands(
+    hooks?.postCheck ?? [],
+    {
+      ...env,
+      CLAUDE_LSP_DIAGNOSTIC_COUNT: String(diagnosticCount),
+      CLAUDE_LSP_EXIT_CODE: hasErrors ? '1' : '0',
+    },
+    hooks?.timeoutMs
+  );
+  if (postFailure) {
+    process.stderr.write(`\n⚠ postCheck hook failed: ${postFailure}\n`);
+  }
+  return hasErrors;
+}
@@ -0,0 +1,540 @@
+import { existsSync } from 'fs';
+import { GO_MOD_MODES, type GoModMode } from '../../checkers/go';
+import type { Diagnostic } from '../../file-checker';
+import {
+  LANGUAGE_EXTENSIONS,
+  isSupportedLanguage,
+  type SupportedLanguage,
+} from '../../language-extensions';
+import {
+  LOG_FORMATS,
+  LOG_LEVELS,
+  isLogLevel,
+  type LogFormat,
+  type LogLevel,
+} from '../../utils/logger';
+import { OUTPUT_FORMATS } from '../formatters';
+import type { CheckHooks, ReportListener } from './check-hooks';
+import { parseSeverityLevel } from './diagnostic-filters';
+import { DEFAULT_PROFILE_PATHS, PROFILE_KINDS, type ProfileKind } from './profiler';
+import { DEFAULT_CONTEXT_LINES } from './source-context';
+
+/**
+ * Options accepted by the check command
+ */
+export interface CheckOptions {
+  /** Output format (default: text) */
+  format?: string;
+  /** Least severe LSP level to report: 1=error, 2=warning, 3=information, 4=hint */
+  minSeverity?: number;
+  /** Alternative global config file (same as CLAUDE_LSP_CONFIG) */
+  config?: string;
+  /** Re-check files whenever they are saved */
+  watch?: boolean;
+  /** Report at most this many diagnostics per file, highest impact first */
+  maxDiagnostics?: number;
+  /** Show progress: undefined = spinner on a TTY only, false = never, true = also log in CI */
+  progress?: boolean;
+  /** false: never write ANSI escape codes, same as NO_COLOR */
+  color?: boolean;
+  /** Show this many source lines around each diagnostic in text output */
+  contextLines?: number;
+  /** Kill a checker command that runs longer than this */
+  timeoutMs?: number;
+  /** Executable to run per tool command, e.g. { go: '/usr/local/go/bin/go' } */
+  toolPaths?: Record<string, string>;
+  /** Skip files larger than this many bytes (default 512 KB, 0 = no limit) */
+  maxFileSize?: number;
+  /** Severity to report per diagnostic code, e.g. { U1000: 'error' } (config files only) */
+  severityOverrides?: Record<string, Diagnostic['severity']>;
+  /** Go build tags for the Go checker (GOFLAGS=-tags=...) */
+  buildTags?: string[];
+  /** Go -mod mode; vendor is detected from vendor/modules.txt otherwise */
+  goMod?: GoModMode;
+  /** Also check Go test files (*_test.go), in a test build of their package */
+  includeTests?: boolean;
+  /** Diagnostic codes to drop before severity filtering (e.g. SA1006) */
+  excludeCodes?: string[];
+  /** Glob patterns for files to skip (vendor/**, *.pb.go) */
+  exclude?: string[];
+  /** Read source from stdin instead of files (requires language) */
+  stdin?: boolean;
+  /** Language key from LANGUAGE_EXTENSIONS, e.g. 'go' */
+  language?: SupportedLanguage;
+  /** Only check files changed since this git ref ('' = $GITHUB_BASE_REF) */
+  sinceCommit?: string;
+  /** Only check staged files and fail on errors (for a git pre-commit hook) */
+  preCommit?: boolean;
+  /** Apply CI defaults and only fail on errors, even if no CI provider is detected */
+  ci?: boolean;
+  /** Only report diagnostics on lines changed since this git ref */
+  blameSince?: string;
+  /** Author email for blameSince (default: git config user.email) */
+  author?: string;
+  /** File listing paths to check, one per line */
+  manifest?: string;
+  /** Number of files checked at the same time */
+  concurrency?: number;
+  /** Report diagnostics sharing a code and symbol once, with a count */
+  group?: boolean;
+  /** Insert claude-lsp-ignore comments above every reported diagnostic */
+  suppress?: boolean;
+  /** Record the run in the diagnostic history database */
+  history?: boolean;
+  /** Print nothing; only the exit code reports whether diagnostics were found */
+  quiet?: boolean;
+  /** Print each checker command, its raw output and phase timings to stderr */
+  verbose?: boolean;
+  /** Write the report to this file instead of stdout/stderr */
+  outputFile?: string;
+  /** Append to outputFile instead of overwriting it */
+  appendOutput?: boolean;
+  /** Enable structured logging at this level */
+  logLevel?: LogLevel;
+  /** Append log entries to this file instead of stderr */
+  logFile?: string;
+  /** Log entry format */
+  logFormat?: LogFormat;
+  /** Write a profile of each kind to its path */
+  profile?: Partial<Record<ProfileKind, string>>;
+  /** Shell commands run before and after the check (config files only) */
+  hooks?: CheckHooks;
+  /** Called with the results as reported, after filtering */
+  onReport?: ReportListener;
+}
+
+/**
+ * Check flags for shell completion. `value` describes what follows the
+ * flag: a fixed list, a file path, or free text; flags without it are
+ * switches. Keep in sync with parseCheckArgs and the help text.
+ */
+export interface CheckFlag {
+  name: string;
+  description: string;
+  value?: readonly string[] | 'file' | 'text';
+}
+
+export const CHECK_FLAGS: CheckFlag[] = [
+  { name: 'format', description: 'Output format', value: OUTPUT_FORMATS },
+  {
+    name: 'min-severity',
+    description: 'Least severe level to report',
+    value: ['error', 'warning', 'information', 'hint'],
+  },
+  { name: 'config', description: 'Alternative config file', value: 'file' },
+  { name: 'watch', description: 'Re-check files whenever they are saved' },
+  { name: 'max-diagnostics', description: 'Diagnostics per file', value: 'text' },
+  { name: 'progress', description: 'Log progress lines outside a terminal' },
+  { name: 'no-progress', description: 'Hide the progress spinner' },
+  { name: 'no-color', description: 'No ANSI escape codes' },
+  { name: 'context-lines', description: 'Source lines around each diagnostic', value: 'text' },
+  { name: 'timeout', description: 'Checker timeout, e.g. 90s', value: 'text' },
+  { name: 'tool-path', description: 'Checker executable as tool=path', value: 'text' },
+  { name: 'exclude', description: 'Glob of files to skip', value: 'text' },
+  { name: 'exclude-code', description: 'Diagnostic codes to skip, comma-separated', value: 'text' },
+  { name: 'max-file-size', description: 'Skip files above a size, e.g. 1MB', value: 'text' },
+  { name: 'stdin', description: 'Check source read from stdin' },
+  {
+    name: 'language',
+    description: 'Check as this language',
+    value: Object.keys(LANGUAGE_EXTENSIONS),
+  },
+  { name: 'since-commit', description: 'Only files changed since a git ref', value: 'text' },
+  { name: 'blame-since', description: 'Only lines you changed since a git ref', value: 'text' },
+  { name: 'author', description: 'Author email for --blame-since', value: 'text' },
+  { name: 'pre-commit', description: 'Check staged files, fail on errors' },
+  { name: 'ci', description: 'CI defaults, fail on errors only' },
+  { name: 'manifest', description: 'File listing paths to check', value: 'file' },
+  { name: 'concurrency', description: 'Files checked at once', value: 'text' },
+  { name: 'group', description: 'Collapse diagnostics sharing a cause' },
+  { name: 'suppress', description: 'Insert claude-lsp-ignore comments' },
+  { name: 'build-tags', description: 'Go build tags, comma-separated', value: 'text' },
+  { name: 'mod', description: 'Go module mode', value: GO_MOD_MODES },
+  { name: 'include-tests', description: 'Also check Go test files' },
+  { name: 'history', description: 'Record the run in the history database' },
+  { name: 'quiet', description: 'No output, only the exit code' },
+  { name: 'verbose', description: 'Trace checker commands and output' },
+  { name: 'output-file', description: 'Write the report to a file', value: 'file' },
+  { name: 'append-output', description: 'Append to --output-file' },
+  { name: 'log-level', description: 'Log level', value: Object.keys(LOG_LEVELS) },
+  { name: 'log-file', description: 'Append logs to this file', value: 'file' },
+  { name: 'log-format', description: 'Log format', value: LOG_FORMATS },
+  { name: 'profile', description: 'Write a CPU or memory profile', value: PROFILE_KINDS },
+];
+
+export interface ParsedCheckArgs {
+  files: string[];
+  options: CheckOptions;
+  error?: string;
+}
+
+/**
+ * Parse a duration such as 500ms, 30s or 2m (bare numbers are seconds)
+ */
+export function parseDuration(value: string): number | null {
+  const match = value.trim().match(/^(\d+(?:\.\d+)?)(ms|s|m)?$/);
+  if (!match || !match[1]) {
+    return null;
+  }
+  const unit = match[2] === 'ms' ? 1 : match[2] === 'm' ? 60000 : 1000;
+  const ms = Math.round(parseFloat(match[1]) * unit);
+  return ms > 0 ? ms : null;
+}
+
+/**
+ * Parse a size such as 512KB, 2MB or 4096 (bare numbers are bytes).
+ * 0 is allowed: it turns a size limit off.
+ */
+export function parseSize(value: string): number | null {
+  const match = value.trim().match(/^(\d+(?:\.\d+)?)\s*(b|k|kb|m|mb)?$/i);
+  if (!match || !match[1]) {
+    return null;
+  }
+  const unit = match[2]?.toLowerCase() ?? 'b';
+  const multiplier = unit.startsWith('m') ? 1024 * 1024 : unit.startsWith('k') ? 1024 : 1;
+  return Math.round(parseFloat(match[1]) * multiplier);
+}
+
+/**
+ * Split check command arguments into file paths and options.
+ * Supports both `--flag value` and `--flag=value` forms.
+ */
+export function parseCheckArgs(args: string[]): ParsedCheckArgs {
+  const files: string[] = [];
+  const options: CheckOptions = {};
+
+  for (let i = 0; i < args.length; i++) {
+    const arg = args[i];
+    if (arg === undefined) continue;
+
+    if (!arg.startsWith('--')) {
+      files.push(arg);
+      continue;
+    }
+
+    const eqIndex = arg.indexOf('=');
+    const name = eqIndex === -1 ? arg.slice(2) : arg.slice(2, eqIndex);
+    let value: string | undefined = eqIndex === -1 ? undefined : arg.slice(eqIndex + 1);
+
+    // Consume the next argument as the value when not given inline
+    const takeValue = (): string | undefined => {
+      if (value === undefined) {
+        const next = args[i + 1];
+        if (next !== undefined && !next.startsWith('--')) {
+          value = next;
+          i++;
+        }
+      }
+      return value;
+    };
+
+    switch (name) {
+      case 'format': {
+        const format = takeValue();
+        if (!format || !OUTPUT_FORMATS.includes(format)) {
+          return {
+            files,
+            options,
+            error: `Invalid --format value: ${format ?? ''}. Valid formats: ${OUTPUT_FORMATS.join(', ')}`,
+          };
+        }
+        options.format = format;
+        break;
+      }
+      case 'min-severity': {
+        const raw = takeValue();
+        const level = raw === undefined ? null : parseSeverityLevel(raw);
+        if (level === null) {
+          return {
+            files,
+            options,
+            error: `Invalid --min-severity value: ${raw ?? ''}. Use 1-4 or error, warning, information, hint`,
+          };
+        }
+        options.minSeverity = level;
+        break;
+      }
+      case 'config': {
+        const path = takeValue();
+        if (!path) {
+          return { files, options, error: '--config requires a file path' };
+        }
+        options.config = path;
+        break;
+      }
+      case 'watch':
+        options.watch = true;
+        break;
+      case 'max-diagnostics': {
+        const raw = takeValue();
+        if (!raw || !/^[1-9]\d*$/.test(raw)) {
+          return {
+            files,
+            options,
+            error: `Invalid --max-diagnostics value: ${raw ?? ''}. Expected a positive integer`,
+          };
+        }
+        options.maxDiagnostics = parseInt(raw, 10);
+        break;
+      }
+      case 'progress':
+        options.progress = true;
+        break;
+      case 'no-progress':
+        options.progress = false;
+        break;
+      case 'no-color':
+        options.color = false;
+        break;
+      case 'context-lines': {
+        // The count is optional, so only consume a numeric next argument
+        const next = args[i + 1];
+        if (value === undefined && next !== undefined && /^\d+$/.test(next)) {
+          value = next;
+          i++;
+        }
+        const raw = value ?? String(DEFAULT_CONTEXT_LINES);
+        if (!/^\d+$/.test(raw)) {
+          return {
+            files,
+            options,
+            error: `Invalid --context-lines value: ${raw}. Expected a non-negative integer`,
+          };
+        }
+        options.contextLines = parseInt(raw, 10);
+        break;
+      }
+      case 'timeout': {
+        const raw = takeValue();
+        const ms = raw === undefined ? null : parseDuration(raw);
+        if (ms === null) {
+          return {
+            files,
+            options,
+            error: `Invalid --timeout value: ${raw ?? ''}. Use e.g. 500ms, 30s or 2m`,
+          };
+        }
+        options.timeoutMs = ms;
+        break;
+      }
+      case 'tool-path': {
+        const raw = takeValue();
+        const match = raw?.match(/^([^=]+)=(.+)$/);
+        if (!match || !match[1] || !match[2]) {
+          return {
+            files,
+            options,
+            error: `Invalid --tool-path value: ${raw ?? ''}. Use <tool>=<path>, e.g. go=/usr/local/go/bin/go`,
+          };
+        }
+        // Repeatable: one --tool-path per tool
+        options.toolPaths = { ...options.toolPaths, [match[1]]: match[2] };
+        break;
+      }
+      case 'exclude': {
+        const pattern = takeValue();
+        if (!pattern) {
+          return { files, options, error: '--exclude requires a glob pattern' };
+        }
+        // Repeatable: each --exclude adds a pattern
+        options.exclude = [...(options.exclude ?? []), pattern];
+        break;
+      }
+      case 'exclude-code': {
+        const codes = takeValue()
+          ?.split(',')
+          .map((code) => code.trim())
+          .filter(Boolean);
+        if (!codes || codes.length === 0) {
+          return { files, options, error: '--exclude-code requires comma-separated codes' };
+        }
+        // Repeatable: codes from every --exclude-code add up
+        options.excludeCodes = [...(options.excludeCodes ?? []), ...codes];
+        break;
+      }
+      case 'max-file-size': {
+        const raw = takeValue();
+        const bytes = raw === undefined ? null : parseSize(raw);
+        if (bytes === null) {
+          return {
+            files,
+            options,
+            error: `Invalid --max-file-size value: ${raw ?? ''}. Use e.g. 512KB, 2MB or 0`,
+          };
+        }
+        options.maxFileSize = bytes;
+        break;
+      }
+      case 'stdin':
+        options.stdin = true;
+        break;
+      case 'language': {
+        const language = takeValue()?.toLowerCase();
+        if (!language || !isSupportedLanguage(language)) {
+          return {
+            files,
+            options,
+            error: `Unknown --language value: ${language ?? ''}. Supported languages: ${Object.keys(LANGUAGE_EXTENSIONS).join(', ')}`,
+          };
+        }
+        options.language = language;
+        break;
+      }
+      case 'since-commit': {
+        // Without a ref, fall back to the pull request base branch at run time. The ref is
+        // optional, so an existing path after it is a file to check (--since-commit src/)
+        const next = args[i + 1];
+        options.sinceCommit =
+          value === undefined && next !== undefined && existsSync(next) ? '' : (takeValue() ?? '');
+        break;
+      }
+      case 'blame-since': {
+        const ref = takeValue();
+        if (!ref) {
+          return { files, options, error: '--blame-since requires a git ref' };
+        }
+        options.blameSince = ref;
+        break;
+      }
+      case 'author': {
+        const email = takeValue();
+        if (!email) {
+          return { files, options, error: '--author requires an email address' };
+        }
+        options.author = email;
+        break;
+      }
+      case 'pre-commit':
+        options.preCommit = true;
+        break;
+      case 'ci':
+        options.ci = true;
+        break;
+      case 'manifest': {
+        const path = takeValue();
+        if (!path) {
+          return { files, options, error: '--manifest requires a file path' };
+        }
+        options.manifest = path;
+        break;
+      }
+      case 'concurrency': {
+        const raw = takeValue();
+        if (!raw || !/^[1-9]\d*$/.test(raw)) {
+          return {
+            files,
+            options,
+            error: `Invalid --concurrency value: ${raw ?? ''}. Expected a positive integer`,
+          };
+        }
+        options.concurrency = parseInt(raw, 10);
+        break;
+      }
+      case 'group':
+        options.group = true;
+        break;
+      case 'suppress':
+        options.suppress = true;
+        break;
+      case 'build-tags': {
+        const tags = takeValue()
+          ?.split(',')
+          .map((tag) => tag.trim())
+          .filter(Boolean);
+        if (!tags || tags.length === 0) {
+          return { files, options, error: '--build-tags requires comma-separated tags' };
+        }
+        options.buildTags = tags;
+        break;
+      }
+      case 'mod': {
+        const mode = takeValue();
+        const goMod = GO_MOD_MODES.find((known) => known === mode);
+        if (!goMod) {
+          return {
+            files,
+            options,
+            error: `Invalid --mod value: ${mode ?? ''}. Use vendor, mod or readonly`,
+          };
+        }
+        options.goMod = goMod;
+        break;
+      }
+      case 'include-tests':
+        options.includeTests = true;
+        break;
+      case 'history':
+        options.history = true;
+        break;
+      case 'quiet':
+        options.quiet = true;
+        break;
+      case 'verbose':
+        options.verbose = true;
+        break;
+      case 'output-file': {
+        const path = takeValue();
+        if (!path) {
+          return { files, options, error: '--output-file requires a file path' };
+        }
+        options.outputFile = path;
+        break;
+      }
+      case 'append-output':
+        options.appendOutput = true;
+        break;
+      case 'log-level': {
+        const level = takeValue()?.toLowerCase();
+        if (!level || !isLogLevel(level)) {
+          return {
+            files,
+            options,
+            error: `Invalid --log-level value: ${level ?? ''}. Use ${Object.keys(LOG_LEVELS).join(', ')}`,
+          };
+        }
+        options.logLevel = level;
+        break;
+      }
+      case 'log-file': {
+        const path = takeValue();
+        if (!path) {
+          return { files, options, error: '--log-file requires a file path' };
+        }
+        options.logFile = path;
+        break;
+      }
+      case 'log-format': {
+        const format = takeValue();
+        const logFormat = LOG_FORMATS.find((candidate) => candidate === format);
+        if (!logFormat) {
+          return {
+            files,
+            options,
+            error: `Invalid --log-format value: ${format ?? ''}. Use ${LOG_FORMATS.join(', ')}`,
+          };
+        }
+        options.logFormat = logFormat;
+        break;
+      }
+      case 'profile': {
+        // Repeatable: --profile cpu --profile mem=heap.json
+        const raw = takeValue();
+        const match = raw?.match(/^(\w+)(?:=(.+))?$/);
+        const kind = PROFILE_KINDS.find((candidate) => candidate === match?.[1]);
+        if (!kind) {
+          return {
+            files,
+            options,
+            error: `Invalid --profile value: ${raw ?? ''}. Use cpu or mem, optionally =<path>`,
+          };
+        }
+        options.profile = { ...options.profile, [kind]: match?.[2] ?? DEFAULT_PROFILE_PATHS[kind] };
+        break;
+      }
+      default:
+        return { files, options, error: `Unknown option: --${name}` };
+    }
+  }
+
+  return { files, options };
+}
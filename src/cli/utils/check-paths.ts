@@ -0,0 +1,62 @@
+import { existsSync, readFileSync, readdirSync, statSync } from 'fs';
+import { dirname, join, resolve } from 'path';
+import { homedir } from 'os';
+import { hasRegisteredChecker } from '../../checkers/plugins';
+import { detectLanguage } from '../../language-extensions';
+
+/**
+ * Expand check arguments into file paths.
+ * A directory is checked as a unit: every supported source file directly
+ * inside it (not in subdirectories) is included, in name order. That
+ * includes extensionless scripts whose shebang names a supported language
+ * and files a plugin checker handles. Other paths are passed through unchanged.
+ */
+export function expandCheckPaths(paths: string[]): string[] {
+  const files: string[] = [];
+
+  for (const path of paths) {
+    const absolutePath = resolve(path);
+    if (!existsSync(absolutePath) || !statSync(absolutePath).isDirectory()) {
+      files.push(path);
+      continue;
+    }
+
+    const entries = readdirSync(absolutePath, { withFileTypes: true })
+      .filter((entry) => entry.isFile())
+      .map((entry) => join(path, entry.name))
+      .filter((file) => detectLanguage(file) !== null || hasRegisteredChecker(file))
+      .sort();
+    files.push(...entries);
+  }
+
+  return files;
+}
+
+/**
+ * Read paths from a manifest file, one per line. Blank lines and lines
+ * starting with # are ignored, ~ expands to the home directory, and
+ * relative paths resolve against the manifest's directory. Duplicates are
+ * dropped; missing paths produce a warning but are otherwise skipped.
+ */
+export function readManifest(
+  manifestPath: string,
+  warn: (_message: string) => void = (message) => console.error(message)
+): string[] {
+  const baseDir = dirname(resolve(manifestPath));
+  const paths = new Set<string>();
+
+  for (const rawLine of readFileSync(manifestPath, 'utf8').split('\n')) {
+    const line = rawLine.trim();
+    if (!line || line.startsWith('#')) continue;
+
+    const expanded = line === '~' || line.startsWith('~/') ? join(homedir(), line.slice(1)) : line;
+    const path = resolve(baseDir, expanded);
+    if (!existsSync(path)) {
+      warn(`⚠ ${manifestPath}: ${line} does not exist, skipping`);
+      continue;
+    }
+    paths.add(path);
+  }
+
+  return [...paths];
+}
@@ -0,0 +1,48 @@
+import type { CheckOptions } from './check-options';
+
+export type CIEnvironment = 'github' | 'gitlab' | 'circleci' | 'buildkite' | 'jenkins' | 'generic';
+
+// Checked in order: most providers also set the generic CI variable
+const CI_VARIABLES: Array<[string, CIEnvironment]> = [
+  ['GITHUB_ACTIONS', 'github'],
+  ['GITLAB_CI', 'gitlab'],
+  ['CIRCLECI', 'circleci'],
+  ['BUILDKITE', 'buildkite'],
+  ['JENKINS_URL', 'jenkins'],
+  ['CI', 'generic'],
+];
+
+/**
+ * Detect the CI provider from its environment variables
+ */
+export function detectCIEnvironment(
+  env: Record<string, string | undefined> = process.env
+): CIEnvironment | null {
+  for (const [variable, environment] of CI_VARIABLES) {
+    const value = env[variable];
+    if (value && value !== 'false' && value !== '0') {
+      return environment;
+    }
+  }
+  return null;
+}
+
+/**
+ * Defaults applied by --ci: the provider's native report format, errors
+ * only, info logging, no colors and no spinner. Config files and flags
+ * still override them.
+ */
+export function ciDefaults(environment: CIEnvironment): CheckOptions {
+  const defaults: CheckOptions = {
+    logLevel: 'info',
+    progress: false,
+    color: false,
+    minSeverity: 1,
+  };
+  if (environment === 'github') {
+    defaults.format = 'github';
+  } else if (environment === 'jenkins') {
+    defaults.format = 'junit';
+  }
+  return defaults;
+}
@@ -0,0 +1,14 @@
+/**
+ * Whether ANSI escape codes (spinner, screen clearing) may be written to
+ * the stream: never when NO_COLOR is non-empty (https://no-color.org, also
+ * set by --no-color), when TERM=dumb, or when the stream is not a terminal.
+ */
+export function shouldColorize(
+  stream: { isTTY?: boolean },
+  env: Record<string, string | undefined> = process.env
+): boolean {
+  if (env.NO_COLOR || env.TERM === 'dumb') {
+    return false;
+  }
+  return !!stream.isTTY;
+}
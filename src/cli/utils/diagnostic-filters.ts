@@ -0,0 +1,151 @@
+import type { Diagnostic, FileCheckResult } from '../../file-checker';
+import { referencedSymbol } from './diagnostic-groups';
+
+// LSP DiagnosticSeverity levels (lower is more severe)
+export const SEVERITY_LEVELS: Record<string, number> = {
+  error: 1,
+  warning: 2,
+  information: 3,
+  info: 3,
+  hint: 4,
+};
+
+/**
+ * Parse a --min-severity value: numeric (1-4) or a named level
+ */
+export function parseSeverityLevel(value: string): number | null {
+  const normalized = value.trim().toLowerCase();
+  if (/^[1-4]$/.test(normalized)) {
+    return parseInt(normalized, 10);
+  }
+  return SEVERITY_LEVELS[normalized] ?? null;
+}
+
+/**
+ * Map a checker severity onto its LSP severity level
+ */
+export function severityLevel(severity: Diagnostic['severity']): number {
+  return SEVERITY_LEVELS[severity] ?? 3;
+}
+
+/**
+ * Parse a severityOverrides value onto a checker severity; hint has
+ * no checker severity of its own and becomes info
+ */
+export function parseCheckerSeverity(value: string): Diagnostic['severity'] | null {
+  const level = parseSeverityLevel(value);
+  if (level === null) {
+    return null;
+  }
+  return level === 1 ? 'error' : level === 2 ? 'warning' : 'info';
+}
+
+/**
+ * Replace the severity of diagnostics whose code has an override
+ * (e.g. { U1000: 'error' }), before severity filtering sees them
+ */
+export function remapSeverity(
+  results: FileCheckResult[],
+  overrides: Record<string, Diagnostic['severity']>
+): FileCheckResult[] {
+  return results.map((result) => ({
+    ...result,
+    diagnostics: result.diagnostics.map((diag) => {
+      const severity = diag.code === undefined ? undefined : overrides[diag.code];
+      return severity ? { ...diag, severity } : diag;
+    }),
+  }));
+}
+
+/**
+ * Drop diagnostics whose code is in codes (e.g. known false positives like SA1006)
+ */
+export function filterByCode(
+  results: FileCheckResult[],
+  codes: string[]
+): { results: FileCheckResult[]; filteredCount: number } {
+  const excluded = new Set(codes);
+  let filteredCount = 0;
+
+  const filtered = results.map((result) => {
+    const kept = result.diagnostics.filter((diag) => !diag.code || !excluded.has(diag.code));
+    filteredCount += result.diagnostics.length - kept.length;
+    return { ...result, diagnostics: kept };
+  });
+
+  return { results: filtered, filteredCount };
+}
+
+/**
+ * Drop diagnostics less severe than the given level
+ */
+export function filterByMinSeverity(
+  results: FileCheckResult[],
+  minLevel: number
+): { results: FileCheckResult[]; filteredCount: number } {
+  let filteredCount = 0;
+
+  const filtered = results.map((result) => {
+    const kept = result.diagnostics.filter((diag) => severityLevel(diag.severity) <= minLevel);
+    filteredCount += result.diagnostics.length - kept.length;
+    return { ...result, diagnostics: kept };
+  });
+
+  return { results: filtered, filteredCount };
+}
+
+// Impact of a diagnostic before counting its cascade: one error outweighs many warnings
+const IMPACT_WEIGHTS: Record<string, number> = { error: 100, warning: 10, info: 1 };
+
+function impactWeight(severity: Diagnostic['severity']): number {
+  return IMPACT_WEIGHTS[severity] ?? 1;
+}
+
+/**
+ * Set each diagnostic's impact: its severity weight plus the number of other
+ * diagnostics mentioning the same symbol, so the source of a cascade ranks first
+ */
+export function scoreImpact(results: FileCheckResult[]): FileCheckResult[] {
+  const symbolCounts = new Map<string, number>();
+  for (const result of results) {
+    for (const diag of result.diagnostics) {
+      const symbol = referencedSymbol(diag.message, diag.code);
+      if (symbol !== null) {
+        symbolCounts.set(symbol, (symbolCounts.get(symbol) ?? 0) + 1);
+      }
+    }
+  }
+
+  return results.map((result) => ({
+    ...result,
+    diagnostics: result.diagnostics.map((diag) => {
+      const symbol = referencedSymbol(diag.message, diag.code);
+      const cascade = symbol === null ? 0 : (symbolCounts.get(symbol) ?? 1) - 1;
+      return { ...diag, impact: impactWeight(diag.severity) + cascade };
+    }),
+  }));
+}
+
+/**
+ * Keep at most `max` diagnostics per file, highest impact first
+ * (diagnostics without a score rank by severity)
+ */
+export function truncateDiagnostics(
+  results: FileCheckResult[],
+  max: number
+): { results: FileCheckResult[]; omittedCount: number } {
+  let omittedCount = 0;
+  const rank = (diag: Diagnostic): number => diag.impact ?? impactWeight(diag.severity);
+
+  const truncated = results.map((result) => {
+    if (result.diagnostics.length <= max) {
+      return result;
+    }
+    // Array.prototype.sort is stable, so tool order is kept between equal scores
+    const kept = [...result.diagnostics].sort((a, b) => rank(b) - rank(a)).slice(0, max);
+    omittedCount += result.diagnostics.length - kept.length;
+    return { ...result, diagnostics: kept };
+  });
+
+  return { results: truncated, omittedCount };
+}
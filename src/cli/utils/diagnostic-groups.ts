@@ -0,0 +1,90 @@
+import type { FileCheckResult } from '../../file-checker';
+
+// Groups spanning more files than this are most likely one missing declaration
+const ROOT_CAUSE_FILE_COUNT = 3;
+
+// Codes whose quoted identifier is the unresolved name itself; other messages
+// quote types or values ("Type 'string' is not assignable to type 'number'")
+const NAME_RESOLUTION_CODES = new Set(['TS2304', 'TS2552', 'reportUndefinedVariable']);
+
+/**
+ * Symbol a diagnostic message is about, e.g. models.User in
+ * "undefined: models.User" or foo in "Cannot find name 'foo'." (TS2304).
+ * Go type mismatches are about the type used: models.ID in
+ * "cannot use id (variable of type models.ID) as string value". Quoted
+ * identifiers only count for name-resolution codes.
+ */
+export function referencedSymbol(message: string, code?: string): string | null {
+  const undefinedName = message.match(/\bundefined: ([\w.$]+)/);
+  if (undefinedName?.[1]) {
+    return undefinedName[1];
+  }
+  const mismatchedType = message.match(/\bcannot use .+? \((?:[\w ]+ )?type ([^)]+)\)/);
+  if (mismatchedType?.[1]) {
+    return mismatchedType[1];
+  }
+  if (code === undefined || !NAME_RESOLUTION_CODES.has(code)) {
+    return null;
+  }
+  const quoted = message.match(/['"`‘]([\w.$:]+)['"`’]/);
+  return quoted?.[1] ?? null;
+}
+
+interface CauseGroup {
+  locations: Array<{ file: string; line: number }>;
+  files: Set<string>;
+}
+
+function causeKey(code: string | undefined, message: string): string | null {
+  const symbol = referencedSymbol(message, code);
+  return symbol === null ? null : `${code ?? ''}\0${symbol}`;
+}
+
+/**
+ * Collapse diagnostics sharing a code and referenced symbol into the first
+ * one, whose message gets the size of the group and the other locations.
+ * Diagnostics that name no symbol are kept as they are.
+ */
+export function groupByCause(results: FileCheckResult[]): {
+  results: FileCheckResult[];
+  groupedCount: number;
+} {
+  const groups = new Map<string, CauseGroup>();
+  for (const result of results) {
+    for (const diag of result.diagnostics) {
+      const key = causeKey(diag.code, diag.message);
+      if (key === null) continue;
+      const group = groups.get(key) ?? { locations: [], files: new Set<string>() };
+      group.locations.push({ file: result.file, line: diag.line });
+      group.files.add(result.file);
+      groups.set(key, group);
+    }
+  }
+
+  let groupedCount = 0;
+  const reported = new Set<string>();
+  const grouped = results.map((result) => ({
+    ...result,
+    diagnostics: result.diagnostics.flatMap((diag) => {
+      const key = causeKey(diag.code, diag.message);
+      const group = key === null ? undefined : groups.get(key);
+      if (key === null || !group || group.locations.length === 1) {
+        return [diag];
+      }
+      if (reported.has(key)) {
+        groupedCount++;
+        return [];
+      }
+      reported.add(key);
+      const rootCause =
+        group.files.size > ROOT_CAUSE_FILE_COUNT ? '; likely a single root cause' : '';
+      const others = group.locations.slice(1);
+      const otherFiles = new Set(others.map((location) => location.file)).size;
+      const more = `+${others.length} more in ${otherFiles} ${otherFiles === 1 ? 'file' : 'files'}`;
+      const listed = others.map((location) => `${location.file}:${location.line}`).join(', ');
+      return [{ ...diag, message: `${diag.message} (${more}: ${listed}${rootCause})` }];
+    }),
+  }));
+
+  return { results: grouped, groupedCount };
+}
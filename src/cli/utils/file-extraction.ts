@@ -1,3 +1,5 @@
+import { hasRegisteredChecker } from '../../checkers/plugins';
+
 interface HookData {
   tool_input?: {
     file_path?: string;
@@ -29,8 +31,10 @@ export function extractFilePaths(hookData: unknown): string[] {
 
   for (const candidate of candidates) {
     if (candidate && typeof candidate === 'string') {
+      // Edited files with other extensions may still have a plugin checker
       if (
-        candidate.match(/\.(ts|tsx|mts|cts|py|go|rs|java|c|cpp|cc|cxx|php|scala|lua|tf|ex|exs)$/i)
+        candidate.match(/\.(ts|tsx|mts|cts|js|jsx|mjs|cjs|py|go|rs|java|c|cpp|cc|cxx|php|scala|lua|tf|ex|exs)$/i) ||
+        hasRegisteredChecker(candidate)
       ) {
         files.push(candidate);
       }
@@ -41,7 +45,7 @@ export function extractFilePaths(hookData: unknown): string[] {
   if (data?.tool_response?.output) {
     const output = data.tool_response.output;
     const fileRegex =
-      /(?:^|\s|["'])([^\s"']*[/\\]?[^\s"']*\.(?:ts|tsx|mts|cts|py|go|rs|java|c|cpp|cc|cxx|php|scala|lua|tf|ex|exs))(?=$|\s|["'])/gim;
+      /(?:^|\s|["'])([^\s"']*[/\\]?[^\s"']*\.(?:ts|tsx|mts|cts|js|jsx|mjs|cjs|py|go|rs|java|c|cpp|cc|cxx|php|scala|lua|tf|ex|exs))(?=$|\s|["'])/gim;
     let match;
     while ((match = fileRegex.exec(output)) !== null) {
       if (match[1]) {
@@ -54,7 +58,7 @@ export function extractFilePaths(hookData: unknown): string[] {
   if (files.length === 0 && data?.tool_input?.command) {
     const command = data.tool_input.command;
     const fileRegex =
-      /(?:^|\s|["'])([^\s"']*[/\\]?[^\s"']*\.(?:ts|tsx|mts|cts|py|go|rs|java|c|cpp|cc|cxx|php|scala|lua|tf|ex|exs))(?=$|\s|["'])/gim;
+      /(?:^|\s|["'])([^\s"']*[/\\]?[^\s"']*\.(?:ts|tsx|mts|cts|js|jsx|mjs|cjs|py|go|rs|java|c|cpp|cc|cxx|php|scala|lua|tf|ex|exs))(?=$|\s|["'])/gim;
     let match;
     while ((match = fileRegex.exec(command)) !== null) {
       if (match[1]) {
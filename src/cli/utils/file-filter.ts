@@ -0,0 +1,93 @@
+import { statSync } from 'fs';
+import { basename, relative, resolve, sep } from 'path';
+
+/**
+ * Whether a file matches any exclude pattern.
+ * Patterns are globs relative to cwd with `**` matching directory trees
+ * (e.g. `vendor/**`). Patterns without a slash match the file name in any
+ * directory (e.g. `*.pb.go`).
+ */
+export function shouldExclude(
+  filePath: string,
+  patterns: string[],
+  cwd: string = process.cwd()
+): boolean {
+  const relativePath = relative(cwd, resolve(cwd, filePath)).split(sep).join('/');
+  const name = basename(filePath);
+
+  return patterns.some((pattern) => {
+    const glob = new Bun.Glob(pattern);
+    return pattern.includes('/') ? glob.match(relativePath) : glob.match(name);
+  });
+}
+
+/**
+ * Drop excluded files, reporting how many were skipped
+ */
+export function applyExcludes(
+  files: string[],
+  patterns: string[] | undefined
+): { files: string[]; skippedCount: number } {
+  if (!patterns || patterns.length === 0) {
+    return { files, skippedCount: 0 };
+  }
+  const kept = files.filter((file) => !shouldExclude(file, patterns));
+  return { files: kept, skippedCount: files.length - kept.length };
+}
+
+/**
+ * Go test files only type-check in a test build of their package, so they
+ * are left out unless asked for (--include-tests)
+ */
+export function applyTestFilter(
+  files: string[],
+  includeTests: boolean | undefined
+): { files: string[]; testCount: number } {
+  if (includeTests) {
+    return { files, testCount: 0 };
+  }
+  const kept = files.filter((file) => !file.endsWith('_test.go'));
+  return { files: kept, testCount: files.length - kept.length };
+}
+
+// Generated files beyond this size slow checkers down without useful diagnostics
+export const DEFAULT_MAX_FILE_SIZE = 512 * 1024;
+
+export interface OversizedFile {
+  file: string;
+  size: number;
+}
+
+/**
+ * Drop files larger than maxBytes (0 disables the limit), reporting each
+ * skipped file with its size
+ */
+export function applyMaxFileSize(
+  files: string[],
+  maxBytes: number = DEFAULT_MAX_FILE_SIZE
+): { files: string[]; tooLarge: OversizedFile[] } {
+  if (maxBytes === 0) {
+    return { files, tooLarge: [] };
+  }
+  const kept: string[] = [];
+  const tooLarge: OversizedFile[] = [];
+  for (const file of files) {
+    const size = statSync(file, { throwIfNoEntry: false })?.size ?? 0;
+    if (size > maxBytes) {
+      tooLarge.push({ file, size });
+    } else {
+      kept.push(file);
+    }
+  }
+  return { files: kept, tooLarge };
+}
+
+/**
+ * Human-readable size, e.g. 600 KB or 2.1 MB
+ */
+export function formatFileSize(bytes: number): string {
+  if (bytes >= 1024 * 1024) {
+    return `${(bytes / (1024 * 1024)).toFixed(1)} MB`;
+  }
+  return bytes >= 1024 ? `${Math.round(bytes / 1024)} KB` : `${bytes} B`;
+}
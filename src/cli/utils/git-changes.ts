@@ -0,0 +1,220 @@
+import { dirname, extname, isAbsolute, join, relative, resolve } from 'path';
+import type { FileCheckResult } from '../../file-checker';
+import { isExtensionSupported } from '../../language-extensions';
+import { execCommand } from '../../utils/common';
+
+export interface ChangedFiles {
+  files: string[];
+  error?: string;
+}
+
+async function git(args: string[], cwd: string): Promise<{ stdout: string; ok: boolean }> {
+  try {
+    const { stdout, exitCode } = await execCommand(['git', ...args], { cwd });
+    return { stdout, ok: exitCode === 0 };
+  } catch {
+    return { stdout: '', ok: false };
+  }
+}
+
+/**
+ * The ref itself when it names a commit, else origin/<ref> when that does
+ * (e.g. GITHUB_BASE_REF in a pull request), else null
+ */
+export async function resolveRef(ref: string, cwd: string = process.cwd()): Promise<string | null> {
+  for (const candidate of [ref, `origin/${ref}`]) {
+    if ((await git(['rev-parse', '--verify', '--quiet', `${candidate}^{commit}`], cwd)).ok) {
+      return candidate;
+    }
+  }
+  return null;
+}
+
+/**
+ * Supported source files added, copied, modified or renamed between ref
+ * and HEAD, as absolute paths. A branch name that only exists on origin
+ * (e.g. GITHUB_BASE_REF in a pull request) is resolved as origin/<ref>.
+ */
+export async function collectChangedFiles(
+  ref: string,
+  cwd: string = process.cwd()
+): Promise<ChangedFiles> {
+  const root = await git(['rev-parse', '--show-toplevel'], cwd);
+  if (!root.ok) {
+    return { files: [], error: `--since-commit: ${cwd} is not inside a git repository` };
+  }
+
+  const base = await resolveRef(ref, cwd);
+  if (!base) {
+    return {
+      files: [],
+      error: `--since-commit: unknown git ref "${ref}" (not a commit, branch or tag here or on origin)`,
+    };
+  }
+
+  const diff = await git(['diff', '--name-only', '--diff-filter=ACMR', base, 'HEAD'], cwd);
+  if (!diff.ok) {
+    return { files: [], error: `--since-commit: git diff against "${base}" failed` };
+  }
+
+  return { files: supportedFiles(diff.stdout, root.stdout) };
+}
+
+/**
+ * Supported source files added, copied, modified or renamed in the index,
+ * as absolute paths. Used by --pre-commit.
+ */
+export async function collectStagedFiles(cwd: string = process.cwd()): Promise<ChangedFiles> {
+  const root = await git(['rev-parse', '--show-toplevel'], cwd);
+  if (!root.ok) {
+    return { files: [], error: `--pre-commit: ${cwd} is not inside a git repository` };
+  }
+
+  const diff = await git(['diff', '--cached', '--name-only', '--diff-filter=ACMR'], cwd);
+  if (!diff.ok) {
+    return { files: [], error: '--pre-commit: git diff --cached failed' };
+  }
+  return { files: supportedFiles(diff.stdout, root.stdout) };
+}
+
+// Turn `git diff --name-only` output into absolute paths of checkable files
+function supportedFiles(nameOnly: string, root: string): string[] {
+  return nameOnly
+    .split('\n')
+    .filter((path) => path && isExtensionSupported(extname(path)))
+    .map((path) => join(root, path));
+}
+
+/**
+ * Keep only files inside one of the given paths (files or directories)
+ */
+export function limitToPaths(files: string[], paths: string[]): string[] {
+  if (paths.length === 0) {
+    return files;
+  }
+  const roots = paths.map((path) => resolve(path));
+  return files.filter((file) => roots.some((root) => isInside(root, file)));
+}
+
+function isInside(root: string, path: string): boolean {
+  const rel = relative(root, path);
+  return !rel.startsWith('..') && !isAbsolute(rel);
+}
+
+const toplevels = new Map<string, string | null>();
+
+/**
+ * Repository root for an absolute path: $GITHUB_WORKSPACE when the path is
+ * inside it, else the enclosing git work tree, else null
+ */
+export function repositoryRoot(path: string): string | null {
+  const workspace = process.env.GITHUB_WORKSPACE;
+  if (workspace && isInside(resolve(workspace), path)) {
+    return resolve(workspace);
+  }
+  const dir = dirname(path);
+  if (!toplevels.has(dir)) {
+    const proc = Bun.spawnSync(['git', 'rev-parse', '--show-toplevel'], { cwd: dir });
+    toplevels.set(dir, proc.exitCode === 0 ? proc.stdout.toString().trim() : null);
+  }
+  return toplevels.get(dir) ?? null;
+}
+
+/**
+ * A result's path relative to the repository root with / separators, for
+ * reports that CI resolves against the repository (result.file is relative
+ * to the project root, which can be a subdirectory). Falls back to result.file.
+ */
+export function repositoryPath(result: FileCheckResult): string {
+  const root = result.sourcePath ? repositoryRoot(result.sourcePath) : null;
+  const path =
+    root && result.sourcePath && isInside(root, result.sourcePath)
+      ? relative(root, result.sourcePath)
+      : result.file;
+  return path.replace(/\\/g, '/');
+}
+
+// git blame reports lines that are not committed yet with an all-zero hash
+const UNCOMMITTED = '0'.repeat(40);
+
+export interface BlameLine {
+  commit: string;
+  authorEmail: string;
+}
+
+/**
+ * Map final line numbers to their commit and author from `git blame --porcelain`
+ */
+export function parseBlamePorcelain(output: string): Map<number, BlameLine> {
+  const lines = new Map<number, BlameLine>();
+  // Author details are only printed the first time a commit appears
+  const emails = new Map<string, string>();
+  let current: { commit: string; line: number } | null = null;
+
+  for (const row of output.split('\n')) {
+    if (row.startsWith('\t')) {
+      if (current) {
+        lines.set(current.line, {
+          commit: current.commit,
+          authorEmail: emails.get(current.commit) ?? '',
+        });
+      }
+      current = null;
+      continue;
+    }
+    const header = row.match(/^([0-9a-f]{40}) \d+ (\d+)/);
+    if (header?.[1] && header[2]) {
+      current = { commit: header[1], line: parseInt(header[2], 10) };
+    } else if (current && row.startsWith('author-mail ')) {
+      emails.set(current.commit, row.slice('author-mail '.length).replace(/^<|>$/g, ''));
+    }
+  }
+  return lines;
+}
+
+/**
+ * Keep diagnostics on lines the author last changed in commits reachable
+ * from HEAD but not from ref, plus lines not committed yet. The author
+ * defaults to `git config user.email`. `sourcePath` maps results to the
+ * files on disk; diagnostics git knows nothing about are kept.
+ */
+export async function filterByBlame(
+  results: FileCheckResult[],
+  sourcePath: (_result: FileCheckResult) => string | undefined,
+  ref: string,
+  authorEmail?: string,
+  cwd: string = process.cwd()
+): Promise<{ results: FileCheckResult[]; filteredCount: number }> {
+  const newCommits = new Set(
+    (await git(['rev-list', `${ref}..HEAD`], cwd)).stdout.split('\n').filter(Boolean)
+  );
+  const author = authorEmail || (await git(['config', 'user.email'], cwd)).stdout;
+
+  let filteredCount = 0;
+  const filtered: FileCheckResult[] = [];
+  for (const result of results) {
+    const path = sourcePath(result);
+    if (!path || result.diagnostics.length === 0) {
+      filtered.push(result);
+      continue;
+    }
+    const blame = await git(['blame', '--porcelain', '--', path], cwd);
+    // Untracked files are entirely new
+    if (!blame.ok) {
+      filtered.push(result);
+      continue;
+    }
+    const lines = parseBlamePorcelain(blame.stdout);
+    const diagnostics = result.diagnostics.filter((diag) => {
+      const line = lines.get(diag.line);
+      return (
+        !line ||
+        line.commit === UNCOMMITTED ||
+        (newCommits.has(line.commit) && (!author || line.authorEmail === author))
+      );
+    });
+    filteredCount += result.diagnostics.length - diagnostics.length;
+    filtered.push({ ...result, diagnostics });
+  }
+  return { results: filtered, filteredCount };
+}
@@ -0,0 +1,260 @@
+/**
+ * Diagnostic history - `check --history` records every run in a SQLite
+ * database (~/.claude/lsp-history.db or $CLAUDE_LSP_HISTORY_DB) so progress
+ * can be followed over time: totals per run, and the issues that never go away.
+ * The `history` command reads it back.
+ */
+
+import { Database } from 'bun:sqlite';
+import { mkdirSync } from 'fs';
+import { homedir } from 'os';
+import { dirname, join, resolve } from 'path';
+import type { Diagnostic, FileCheckResult } from '../../file-checker';
+
+export function historyPath(): string {
+  return process.env.CLAUDE_LSP_HISTORY_DB || join(homedir(), '.claude', 'lsp-history.db');
+}
+
+export interface RunSummary {
+  runId: string;
+  /** Unix time in milliseconds */
+  startedAt: number;
+  files: number;
+  errors: number;
+  warnings: number;
+  infos: number;
+}
+
+export interface HistoryDiagnostic {
+  file: string;
+  /** Line in the most recent run; lines move as code is edited around them */
+  line: number;
+  severity: Diagnostic['severity'];
+  code?: string;
+  message: string;
+  tool: string;
+}
+
+export interface FileCheck {
+  runId: string;
+  /** Unix time in milliseconds */
+  startedAt: number;
+  file: string;
+  /** The file's diagnostics in this run, counted by severity and code */
+  counts: Array<{ severity: Diagnostic['severity']; code?: string; n: number }>;
+}
+
+const SCHEMA = `
+  CREATE TABLE IF NOT EXISTS runs (
+    id TEXT PRIMARY KEY,
+    started_at INTEGER NOT NULL
+  );
+  CREATE TABLE IF NOT EXISTS checked_files (
+    run_id TEXT NOT NULL REFERENCES runs(id),
+    file TEXT NOT NULL,
+    tool TEXT NOT NULL
+  );
+  CREATE TABLE IF NOT EXISTS diagnostics (
+    run_id TEXT NOT NULL REFERENCES runs(id),
+    file TEXT NOT NULL,
+    line INTEGER NOT NULL,
+    severity TEXT NOT NULL,
+    code TEXT,
+    message TEXT NOT NULL,
+    tool TEXT NOT NULL
+  );
+  CREATE INDEX IF NOT EXISTS checked_files_file ON checked_files(file);
+  CREATE INDEX IF NOT EXISTS diagnostics_run ON diagnostics(run_id);
+`;
+
+export class DiagnosticStore {
+  private db: Database;
+
+  constructor(path: string = historyPath()) {
+    if (path !== ':memory:') {
+      mkdirSync(dirname(path), { recursive: true });
+    }
+    this.db = new Database(path, { create: true });
+    this.db.exec(SCHEMA);
+  }
+
+  /**
+   * Store the files a run checked and their diagnostics, with absolute paths
+   * so runs from different directories line up. Results without a source
+   * path (from stdin) are resolved against cwd.
+   */
+  recordRun(runId: string, startedAt: Date, results: FileCheckResult[]): void {
+    const insertRun = this.db.prepare('INSERT INTO runs (id, started_at) VALUES (?, ?)');
+    const insertFile = this.db.prepare(
+      'INSERT INTO checked_files (run_id, file, tool) VALUES (?, ?, ?)'
+    );
+    const insertDiagnostic = this.db.prepare(
+      'INSERT INTO diagnostics (run_id, file, line, severity, code, message, tool) ' +
+        'VALUES (?, ?, ?, ?, ?, ?, ?)'
+    );
+
+    this.db.transaction(() => {
+      insertRun.run(runId, startedAt.getTime());
+      for (const result of results) {
+        const file = result.sourcePath ?? resolve(result.file);
+        insertFile.run(runId, file, result.tool);
+        for (const diag of result.diagnostics) {
+          insertDiagnostic.run(
+            runId,
+            file,
+            diag.line,
+            diag.severity,
+            diag.code ?? null,
+            diag.message,
+            result.tool
+          );
+        }
+      }
+    })();
+  }
+
+  /**
+   * Totals by severity for every run since the given time, oldest first.
+   * With a file, only the runs that checked it count, and only its diagnostics.
+   */
+  queryTrend(filePath: string | undefined, since: Date): RunSummary[] {
+    const file = filePath ? resolve(filePath) : null;
+    const inRange =
+      'run_id IN (SELECT id FROM runs WHERE started_at >= ?1) AND (?2 IS NULL OR file = ?2)';
+    const fileCounts = this.db
+      .query(`SELECT run_id, COUNT(*) AS n FROM checked_files WHERE ${inRange} GROUP BY run_id`)
+      .all(since.getTime(), file) as Array<{ run_id: string; n: number }>;
+    const severityCounts = this.db
+      .query(
+        `SELECT run_id, severity, COUNT(*) AS n FROM diagnostics WHERE ${inRange} ` +
+          'GROUP BY run_id, severity'
+      )
+      .all(since.getTime(), file) as Array<{ run_id: string; severity: string; n: number }>;
+
+    const summaries = new Map<string, RunSummary>();
+    const runs = this.db
+      .query('SELECT id, started_at FROM runs WHERE started_at >= ? ORDER BY started_at, rowid')
+      .all(since.getTime()) as Array<{ id: string; started_at: number }>;
+    for (const { id, started_at } of runs) {
+      const counts = { files: 0, errors: 0, warnings: 0, infos: 0 };
+      summaries.set(id, { runId: id, startedAt: started_at, ...counts });
+    }
+    for (const { run_id, n } of fileCounts) {
+      const summary = summaries.get(run_id);
+      if (summary) {
+        summary.files = n;
+      }
+    }
+    const totals = { error: 'errors', warning: 'warnings', info: 'infos' } as const;
+    for (const { run_id, severity, n } of severityCounts) {
+      const summary = summaries.get(run_id);
+      const total = totals[severity as keyof typeof totals];
+      if (summary && total) {
+        summary[total] += n;
+      }
+    }
+
+    // A file's trend only covers the runs that checked it
+    return [...summaries.values()].filter((summary) => !file || summary.files > 0);
+  }
+
+  /**
+   * Every time a file was checked since the given time, oldest first, with
+   * the diagnostics that check found
+   */
+  fileChecks(since: Date): FileCheck[] {
+    const checks = this.db
+      .query(
+        'SELECT cf.run_id AS runId, r.started_at AS startedAt, cf.file FROM checked_files cf ' +
+          'JOIN runs r ON r.id = cf.run_id WHERE r.started_at >= ? ' +
+          'ORDER BY r.started_at, r.rowid, cf.rowid'
+      )
+      .all(since.getTime()) as Array<Omit<FileCheck, 'counts'>>;
+    const counts = this.db
+      .query(
+        'SELECT run_id, file, severity, code, COUNT(*) AS n FROM diagnostics ' +
+          'WHERE run_id IN (SELECT id FROM runs WHERE started_at >= ?) ' +
+          'GROUP BY run_id, file, severity, code'
+      )
+      .all(since.getTime()) as Array<{
+      run_id: string;
+      file: string;
+      severity: Diagnostic['severity'];
+      code: string | null;
+      n: number;
+    }>;
+
+    const byCheck = new Map<string, FileCheck['counts']>();
+    for (const { run_id, file, severity, code, n } of counts) {
+      const key = `${run_id}\0${file}`;
+      const count = code === null ? { severity, n } : { severity, code, n };
+      byCheck.set(key, [...(byCheck.get(key) ?? []), count]);
+    }
+    return checks.map((check) => ({
+      ...check,
+      counts: byCheck.get(`${check.runId}\0${check.file}`) ?? [],
+    }));
+  }
+
+  /**
+   * Diagnostics reported in every one of the last `runs` runs, matched by
+   * file, code and message rather than line. Empty until that many runs exist.
+   */
+  persistentIssues(runs: number): HistoryDiagnostic[] {
+    const recent = this.db
+      .query('SELECT id FROM runs ORDER BY started_at DESC, rowid DESC LIMIT ?')
+      .all(runs) as Array<{ id: string }>;
+    if (runs < 1 || recent.length < runs) {
+      return [];
+    }
+
+    const ids = recent.map((row) => row.id);
+    const placeholders = ids.map(() => '?').join(', ');
+    const rows = this.db
+      .query(
+        `SELECT d.file, d.severity, d.code, d.message, d.tool,
+           (SELECT d2.line FROM diagnostics d2
+             WHERE d2.run_id = ? AND d2.file = d.file AND d2.message = d.message
+             LIMIT 1) AS line
+         FROM diagnostics d
+         WHERE d.run_id IN (${placeholders})
+         GROUP BY d.file, d.code, d.message
+         HAVING COUNT(DISTINCT d.run_id) = ?
+         ORDER BY d.file, line`
+      )
+      .all(ids[0] ?? '', ...ids, runs) as Array<
+      Omit<HistoryDiagnostic, 'code'> & { code: string | null }
+    >;
+
+    return rows.map(({ code, ...diag }) => (code === null ? diag : { ...diag, code }));
+  }
+
+  /**
+   * Runs whose ID starts with prefix, newest first
+   */
+  findRuns(prefix: string): Array<{ runId: string; startedAt: number }> {
+    return this.db
+      .query(
+        'SELECT id AS runId, started_at AS startedAt FROM runs ' +
+          'WHERE substr(id, 1, length(?1)) = ?1 ORDER BY started_at DESC, rowid DESC'
+      )
+      .all(prefix) as Array<{ runId: string; startedAt: number }>;
+  }
+
+  /**
+   * Every diagnostic a run recorded, by file and line
+   */
+  runDiagnostics(runId: string): HistoryDiagnostic[] {
+    const rows = this.db
+      .query(
+        'SELECT file, line, severity, code, message, tool FROM diagnostics ' +
+          'WHERE run_id = ? ORDER BY file, line, rowid'
+      )
+      .all(runId) as Array<Omit<HistoryDiagnostic, 'code'> & { code: string | null }>;
+    return rows.map(({ code, ...diag }) => (code === null ? diag : { ...diag, code }));
+  }
+
+  close(): void {
+    this.db.close();
+  }
+}
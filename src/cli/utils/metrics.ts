@@ -0,0 +1,77 @@
+/**
+ * Prometheus metrics for `serve`, rendered in the text exposition format.
+ * Label values come from fixed sets (languages, severities) so file paths
+ * never turn into series.
+ */
+
+import { extname } from 'path';
+import type { FileCheckResult } from '../../file-checker';
+import { LANGUAGE_EXTENSIONS, getLanguageForExtension } from '../../language-extensions';
+
+// Upper bounds in seconds: checkers range from instant linters to cold cargo builds
+const DURATION_BUCKETS = [0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60];
+
+// Plugin checkers and --language overrides of unknown extensions
+const OTHER_LANGUAGE = 'other';
+
+const SEVERITIES = ['error', 'warning', 'info'] as const;
+
+export class MetricsCollector {
+  private analyses = new Map<string, number>();
+  private diagnostics = new Map<string, number>(SEVERITIES.map((severity) => [severity, 0]));
+  private bucketCounts = DURATION_BUCKETS.map(() => 0);
+  private durationSum = 0;
+  private durationCount = 0;
+
+  /**
+   * Count one /analyze request: its files by language, their diagnostics
+   * by severity, and how long it took
+   */
+  recordAnalysis(results: FileCheckResult[], durationSeconds: number): void {
+    for (const result of results) {
+      const language =
+        result.language ?? getLanguageForExtension(extname(result.file)) ?? OTHER_LANGUAGE;
+      this.analyses.set(language, (this.analyses.get(language) ?? 0) + 1);
+      for (const diag of result.diagnostics) {
+        this.diagnostics.set(diag.severity, (this.diagnostics.get(diag.severity) ?? 0) + 1);
+      }
+    }
+
+    DURATION_BUCKETS.forEach((bound, i) => {
+      if (durationSeconds <= bound) {
+        this.bucketCounts[i] = (this.bucketCounts[i] ?? 0) + 1;
+      }
+    });
+    this.durationSum += durationSeconds;
+    this.durationCount++;
+  }
+
+  render(): string {
+    const languages = [...Object.keys(LANGUAGE_EXTENSIONS), OTHER_LANGUAGE];
+    const lines = [
+      '# HELP claude_lsp_analyses_total Files checked, by language.',
+      '# TYPE claude_lsp_analyses_total counter',
+      ...languages.map(
+        (language) =>
+          `claude_lsp_analyses_total{language="${language}"} ${this.analyses.get(language) ?? 0}`
+      ),
+      '# HELP claude_lsp_diagnostics_total Diagnostics reported, by severity.',
+      '# TYPE claude_lsp_diagnostics_total counter',
+      ...SEVERITIES.map(
+        (severity) =>
+          `claude_lsp_diagnostics_total{severity="${severity}"} ${this.diagnostics.get(severity)}`
+      ),
+      '# HELP claude_lsp_analyze_duration_seconds Time to answer an /analyze request.',
+      '# TYPE claude_lsp_analyze_duration_seconds histogram',
+      // Buckets are counted individually on record, so they are already cumulative
+      ...DURATION_BUCKETS.map(
+        (bound, i) =>
+          `claude_lsp_analyze_duration_seconds_bucket{le="${bound}"} ${this.bucketCounts[i]}`
+      ),
+      `claude_lsp_analyze_duration_seconds_bucket{le="+Inf"} ${this.durationCount}`,
+      `claude_lsp_analyze_duration_seconds_sum ${this.durationSum}`,
+      `claude_lsp_analyze_duration_seconds_count ${this.durationCount}`,
+    ];
+    return lines.join('\n') + '\n';
+  }
+}
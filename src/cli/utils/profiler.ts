@@ -0,0 +1,91 @@
+/**
+ * CPU and memory profiling for --profile
+ *
+ * A CPU profile is the JavaScriptCore sampling profiler's report of the
+ * hottest functions and bytecodes. A memory profile is a heap snapshot
+ * that the Safari / WebKit Web Inspector can open; while it's requested,
+ * heap usage is also logged at info level every 10 seconds, so growth can
+ * be lined up with the rest of the log. Checker processes run outside this
+ * process, so their time only shows up as waiting.
+ */
+
+import { writeFileSync } from 'fs';
+import { profile } from 'bun:jsc';
+import { logger, type LogFields } from '../../utils/logger';
+
+export type ProfileKind = 'cpu' | 'mem';
+
+export const PROFILE_KINDS: readonly ProfileKind[] = ['cpu', 'mem'];
+
+export const DEFAULT_PROFILE_PATHS: Record<ProfileKind, string> = {
+  cpu: 'claude-lsp-cpu-profile.txt',
+  mem: 'claude-lsp-heap-snapshot.json',
+};
+
+// Functions listed in the summary printed after a CPU profile
+const SUMMARY_FUNCTIONS = 5;
+
+export const HEAP_LOG_INTERVAL_MS = 10000;
+
+/**
+ * The first `count` rows of a sampling profiler function report
+ * (lines like `   42    'checkFile#AbCdEf:1'`)
+ */
+export function topFunctions(report: string, count: number = SUMMARY_FUNCTIONS): string[] {
+  return report
+    .split('\n')
+    .filter((line) => /^\s*\d+\s+'/.test(line))
+    .slice(0, count)
+    .map((line) => line.trim());
+}
+
+/**
+ * Log heap and resident memory every intervalMs until the returned stop
+ * function is called
+ */
+export function startHeapLogging(
+  intervalMs: number = HEAP_LOG_INTERVAL_MS,
+  log: (_fields: LogFields) => void = (fields) => logger.info('heap usage', fields)
+): () => void {
+  const timer = setInterval(() => {
+    const { heapUsed, heapTotal, rss } = process.memoryUsage();
+    log({ phase: 'profile', heapUsed, heapTotal, rss });
+  }, intervalMs);
+  // Never keep the process alive just to log
+  timer.unref();
+  return () => clearInterval(timer);
+}
+
+/**
+ * Run work under the requested profilers and write each profile once it
+ * finishes, summarising the hottest functions on stderr
+ */
+export async function withProfiling<T>(
+  paths: Partial<Record<ProfileKind, string>>,
+  work: () => Promise<T>
+): Promise<T> {
+  let result: T | undefined;
+  const run = async (): Promise<void> => {
+    result = await work();
+  };
+
+  const stopHeapLogging = paths.mem ? startHeapLogging() : null;
+  if (paths.cpu) {
+    const sampled = await profile(run);
+    writeFileSync(paths.cpu, `${sampled.functions}\n\n${sampled.bytecodes}\n`);
+    process.stderr.write(`\nCPU profile written to ${paths.cpu}. Top functions:\n`);
+    for (const line of topFunctions(sampled.functions)) {
+      process.stderr.write(`  ${line}\n`);
+    }
+  } else {
+    await run();
+  }
+
+  stopHeapLogging?.();
+  if (paths.mem) {
+    writeFileSync(paths.mem, JSON.stringify(Bun.generateHeapSnapshot(), null, 2));
+    process.stderr.write(`\nHeap snapshot written to ${paths.mem}\n`);
+  }
+
+  return result as T;
+}
@@ -0,0 +1,114 @@
+/**
+ * Progress reporting for long-running checks.
+ * Interactive terminals get a spinner that is cleared before results are
+ * printed; elsewhere (CI logs) each phase is logged on its own line.
+ */
+
+import { shouldColorize } from './color';
+
+export interface ProgressReporter {
+  start(_label: string): void;
+  update(_label: string): void;
+  done(): void;
+}
+
+const SPINNER_FRAMES = ['⠋', '⠙', '⠹', '⠸', '⠼', '⠴', '⠦', '⠧', '⠇', '⠏'];
+const SPINNER_INTERVAL_MS = 80;
+
+export function timestamp(): string {
+  return new Date().toLocaleTimeString('en-US', { hour12: false });
+}
+
+function elapsedSeconds(startedAt: number): string {
+  return ((Date.now() - startedAt) / 1000).toFixed(1);
+}
+
+class SilentProgressReporter implements ProgressReporter {
+  start(_label: string): void {}
+  update(_label: string): void {}
+  done(): void {}
+}
+
+class SpinnerProgressReporter implements ProgressReporter {
+  private label = '';
+  private frame = 0;
+  private startedAt = 0;
+  private timer: ReturnType<typeof setInterval> | null = null;
+  private stream: NodeJS.WriteStream;
+
+  constructor(stream: NodeJS.WriteStream) {
+    this.stream = stream;
+  }
+
+  start(label: string): void {
+    this.label = label;
+    this.startedAt = Date.now();
+    this.render();
+    this.timer = setInterval(() => this.render(), SPINNER_INTERVAL_MS);
+    // Never keep the process alive just to animate
+    this.timer.unref?.();
+  }
+
+  update(label: string): void {
+    this.label = label;
+    this.render();
+  }
+
+  done(): void {
+    if (this.timer) {
+      clearInterval(this.timer);
+      this.timer = null;
+    }
+    this.stream.write('\r\x1b[K');
+  }
+
+  private render(): void {
+    const frame = SPINNER_FRAMES[this.frame++ % SPINNER_FRAMES.length];
+    this.stream.write(`\r\x1b[K${frame} ${this.label} (${elapsedSeconds(this.startedAt)}s)`);
+  }
+}
+
+class LogProgressReporter implements ProgressReporter {
+  private startedAt = 0;
+  private stream: NodeJS.WriteStream;
+
+  constructor(stream: NodeJS.WriteStream) {
+    this.stream = stream;
+  }
+
+  start(label: string): void {
+    this.startedAt = Date.now();
+    this.log(label);
+  }
+
+  update(label: string): void {
+    this.log(label);
+  }
+
+  done(): void {
+    this.log(`Done in ${elapsedSeconds(this.startedAt)}s`);
+  }
+
+  private log(message: string): void {
+    this.stream.write(`[${timestamp()}] ${message}\n`);
+  }
+}
+
+/**
+ * Pick a reporter for the given stream.
+ * `enabled` undefined means automatic: spinner on a TTY, silent otherwise,
+ * so hook and script output is unchanged unless progress is requested.
+ * NO_COLOR and TERM=dumb terminals get log lines instead of the spinner.
+ */
+export function createProgressReporter(
+  enabled?: boolean,
+  stream: NodeJS.WriteStream = process.stderr
+): ProgressReporter {
+  if (enabled === false) {
+    return new SilentProgressReporter();
+  }
+  if (shouldColorize(stream)) {
+    return new SpinnerProgressReporter(stream);
+  }
+  return enabled ? new LogProgressReporter(stream) : new SilentProgressReporter();
+}
@@ -0,0 +1,36 @@
+import { detectLanguage } from '../../language-extensions';
+
+// Used when --context-lines is given without a count
+export const DEFAULT_CONTEXT_LINES = 10;
+
+/**
+ * Return the lines startLine..endLine (1-based, inclusive) plus contextLines
+ * above and below, each prefixed with its line number. Lines inside the
+ * diagnostic range are marked with ">".
+ */
+export function extractContext(
+  sourceLines: string[],
+  startLine: number,
+  endLine: number,
+  contextLines: number
+): string {
+  const first = Math.max(1, startLine - contextLines);
+  const last = Math.min(sourceLines.length, Math.max(startLine, endLine) + contextLines);
+  const width = String(last).length;
+
+  const lines: string[] = [];
+  for (let line = first; line <= last; line++) {
+    const marker = line >= startLine && line <= endLine ? '>' : ' ';
+    lines.push(`${marker} ${String(line).padStart(width)} | ${sourceLines[line - 1] ?? ''}`);
+  }
+  return lines.join('\n');
+}
+
+/**
+ * Wrap a snippet in a fenced code block tagged with the file's language
+ * (from its shebang when it has no extension)
+ */
+export function fenceSnippet(filePath: string, snippet: string): string {
+  const language = detectLanguage(filePath) ?? '';
+  return `\`\`\`${language}\n${snippet}\n\`\`\``;
+}
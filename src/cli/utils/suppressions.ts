@@ -0,0 +1,132 @@
+/**
+ * Inline suppression comments
+ *
+ * A `claude-lsp-ignore` comment hides diagnostics on the line below it:
+ *   // claude-lsp-ignore: TS2322 Type 'string' is not assignable
+ * The text after the colon is matched against the diagnostic: its first
+ * word against the code (several codes are separated by commas, as in
+ * `TS2322,TS6133`), or the whole text as a message prefix for tools that
+ * don't report codes. A bare `claude-lsp-ignore` hides everything on the
+ * next line.
+ */
+
+import type { Diagnostic } from '../../file-checker';
+import { detectLanguage } from '../../language-extensions';
+
+export const SUPPRESS_MARKER = 'claude-lsp-ignore';
+
+// Length of the message excerpt written into inserted comments
+const MESSAGE_SNIPPET_LENGTH = 60;
+
+const HASH_COMMENT_LANGUAGES = new Set(['python', 'elixir', 'terraform']);
+
+export interface Suppression {
+  /** 1-based line of the comment itself */
+  commentLine: number;
+  /** 1-based line whose diagnostics are suppressed */
+  targetLine: number;
+  /** Text after the colon (may be empty) */
+  text: string;
+}
+
+// Extensionless scripts get the comment syntax of the language their shebang names
+export function commentPrefix(filePath: string): string {
+  const language = detectLanguage(filePath);
+  if (language === 'lua') return '--';
+  return language && HASH_COMMENT_LANGUAGES.has(language) ? '#' : '//';
+}
+
+export function findSuppressions(sourceLines: string[]): Suppression[] {
+  const pattern = new RegExp(`(?://|#|--)\\s*${SUPPRESS_MARKER}\\b:?(.*)$`);
+  const suppressions: Suppression[] = [];
+
+  sourceLines.forEach((line, index) => {
+    const match = line.match(pattern);
+    if (match) {
+      suppressions.push({
+        commentLine: index + 1,
+        targetLine: index + 2,
+        text: (match[1] ?? '').trim(),
+      });
+    }
+  });
+
+  return suppressions;
+}
+
+function matches(suppression: Suppression, diag: Diagnostic): boolean {
+  if (diag.line !== suppression.targetLine) return false;
+  if (!suppression.text) return true;
+  const codes = (suppression.text.split(/\s+/)[0] ?? '').split(',');
+  return (!!diag.code && codes.includes(diag.code)) || diag.message.startsWith(suppression.text);
+}
+
+/**
+ * Remove suppressed diagnostics. Comments that match nothing are returned
+ * as stale so they can be cleaned up.
+ */
+export function filterSuppressed(
+  diagnostics: Diagnostic[],
+  sourceLines: string[]
+): { diagnostics: Diagnostic[]; suppressedCount: number; stale: Suppression[] } {
+  const suppressions = findSuppressions(sourceLines);
+  if (suppressions.length === 0) {
+    return { diagnostics, suppressedCount: 0, stale: [] };
+  }
+
+  const used = new Set<Suppression>();
+  const kept = diagnostics.filter((diag) => {
+    const suppression = suppressions.find((s) => matches(s, diag));
+    if (suppression) used.add(suppression);
+    return !suppression;
+  });
+
+  return {
+    diagnostics: kept,
+    suppressedCount: diagnostics.length - kept.length,
+    stale: suppressions.filter((s) => !used.has(s)),
+  };
+}
+
+/**
+ * Comment text covering every diagnostic on one line: their distinct codes
+ * and the first message, or a message prefix for a single uncoded one. A
+ * line mixing uncoded diagnostics with others gets a bare comment.
+ */
+function suppressionText(diagnostics: Diagnostic[]): string {
+  const [first] = diagnostics;
+  const snippet = first?.message.split('\n')[0]?.slice(0, MESSAGE_SNIPPET_LENGTH) ?? '';
+  const codes = [...new Set(diagnostics.map((diag) => diag.code))];
+  if (codes.every((code) => code)) {
+    return `${codes.join(',')} ${snippet}`;
+  }
+  return diagnostics.length === 1 ? snippet : '';
+}
+
+/**
+ * Return the source with a suppression comment above each diagnostic's
+ * line, indented to match it. One comment is written per line.
+ */
+export function insertSuppressions(
+  sourceLines: string[],
+  diagnostics: Diagnostic[],
+  filePath: string
+): string[] {
+  const prefix = commentPrefix(filePath);
+  const byLine = new Map<number, Diagnostic[]>();
+  for (const diag of diagnostics) {
+    byLine.set(diag.line, [...(byLine.get(diag.line) ?? []), diag]);
+  }
+
+  const lines = [...sourceLines];
+  // Insert bottom-up so earlier line numbers stay valid
+  for (const [line, lineDiagnostics] of [...byLine].sort((a, b) => b[0] - a[0])) {
+    const target = lines[line - 1];
+    if (target === undefined) continue;
+    const indent = target.match(/^\s*/)?.[0] ?? '';
+    const text = suppressionText(lineDiagnostics);
+    const comment = text ? `${prefix} ${SUPPRESS_MARKER}: ${text}` : `${prefix} ${SUPPRESS_MARKER}`;
+    lines.splice(line - 1, 0, `${indent}${comment}`.trimEnd());
+  }
+  return lines;
+}
@@ -0,0 +1,24 @@
+import { accessSync, constants, statSync } from 'fs';
+
+/**
+ * Describe the first tool path that can't be run, or return null when all
+ * of them can. Bare command names are looked up on PATH.
+ */
+export function findUnusableToolPath(toolPaths: Record<string, string>): string | null {
+  for (const [tool, path] of Object.entries(toolPaths)) {
+    if (!/[/\\]/.test(path)) {
+      if (!Bun.which(path)) {
+        return `${tool} not found: ${path} is not on PATH`;
+      }
+      continue;
+    }
+    try {
+      accessSync(path, constants.X_OK);
+      if (statSync(path).isFile()) continue;
+    } catch {
+      // Reported below
+    }
+    return `${tool} not found at ${path}; install ${tool} there or fix the tool path`;
+  }
+  return null;
+}
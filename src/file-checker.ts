@@ -6,6 +6,7 @@
  */
 
 import { existsSync } from 'fs';
+import type { SupportedLanguage } from './language-extensions';
 import { findProjectRoot } from './utils/common';
 
 export interface Diagnostic {
@@ -13,14 +14,41 @@ export interface Diagnostic {
   column: number;
   severity: 'error' | 'warning' | 'info';
   message: string;
+  code?: string; // Optional tool-specific diagnostic code (e.g. TS2322)
+  snippet?: string; // Fenced surrounding source, added with --context-lines
+  impact?: number; // Severity weight plus diagnostics sharing its symbol, used for ranking
   file?: string; // Optional file field for when combining multiple files
 }
 
 export interface FileCheckResult {
   file: string;
   tool: string;
+  language?: SupportedLanguage; // Set by check so mixed-language runs can be told apart
+  sourcePath?: string; // Absolute path the file was read from, set by check for snippets etc.
   diagnostics: Array<Diagnostic>;
   timedOut?: boolean;
+  command?: string; // Command line that timed out, so it can be reproduced by hand
+}
+
+// Steps of a checker run, in order
+export type CheckerPhase = 'setup' | 'run' | 'parse';
+
+/**
+ * Per-run overrides for the language checkers
+ */
+export interface CheckerOptions {
+  /** Kill the checker command after this long instead of using the checker's own limit */
+  timeoutMs?: number;
+  /** Executable to run for a tool command, e.g. { go: '/usr/local/go/bin/go' } */
+  toolPaths?: Record<string, string>;
+  /** Called with how long each phase of a checker run took, for benchmarks */
+  onPhase?: (_phase: CheckerPhase, _durationMs: number) => void;
+  /** Print each checker command, its raw output and phase timings to stderr */
+  verbose?: boolean;
+  /** Go build tags, e.g. ['integration', 'linux'] */
+  buildTags?: string[];
+  /** Go -mod mode instead of the detected one (vendor when vendor/modules.txt exists) */
+  goMod?: 'vendor' | 'mod' | 'readonly';
 }
 
 /**
@@ -68,19 +96,22 @@ export function formatDiagnostics(
 }
 
 /**
- * Check a single file using the language registry
+ * Check a single file using the language registry.
+ * `projectRoot` defaults to the nearest project directory above the file.
  */
-export async function checkFile(filePath: string): Promise<FileCheckResult | null> {
+export async function checkFile(
+  filePath: string,
+  options: CheckerOptions = {},
+  projectRoot: string = findProjectRoot(filePath)
+): Promise<FileCheckResult | null> {
   if (!existsSync(filePath)) {
     return null;
   }
 
-  const projectRoot = findProjectRoot(filePath);
-
   // Use registry-based checker
   try {
     const { checkFileWithRegistry } = await import('./generic-checker');
-    const result = await checkFileWithRegistry(filePath, projectRoot);
+    const result = await checkFileWithRegistry(filePath, projectRoot, options);
     return result;
   } catch (_error) {
     // Return null if registry check fails
@@ -7,9 +7,12 @@
 
 import { existsSync } from 'fs';
 import { extname } from 'path';
-import type { FileCheckResult } from './file-checker';
+import { performance } from 'perf_hooks';
+import type { CheckerOptions, CheckerPhase, FileCheckResult } from './file-checker';
 import { LANGUAGE_REGISTRY, findLocalTool, createResult } from './language-checker-registry';
 import { runCommand, isLanguageDisabled } from './utils/common';
+import { logger } from './utils/logger';
+import { ensurePluginsLoaded } from './checkers/plugins';
 
 // Import registry initialization (ensures all languages are registered)
 import './checkers/index';
@@ -17,18 +20,36 @@ import './checkers/index';
 // runCommand, readLspConfig, and isLanguageDisabled are now imported from utils/common
 
 /**
- * Generic language checker that uses the registry
+ * --verbose trace line: `12:00:00.123 [go →] go vet ./...` for what the
+ * checker was sent, ← for its stdout, and just [go] for its stderr and timings
+ */
+function writeVerbose(tool: string, direction: '→' | '←' | '', text: string): void {
+  const time = new Date().toISOString().slice(11, 23);
+  const prefix = direction ? `[${tool} ${direction}]` : `[${tool}]`;
+  for (const line of text.trimEnd().split('\n')) {
+    process.stderr.write(`${time} ${prefix} ${line}\n`);
+  }
+}
+
+/**
+ * Generic language checker that uses the registry.
+ * `options` override the checker's own timeout and tool lookup when given.
  */
 export async function checkFileWithRegistry(
   filePath: string,
-  projectRoot: string
+  projectRoot: string,
+  options: CheckerOptions = {}
 ): Promise<FileCheckResult | null> {
   if (!existsSync(filePath)) {
     return null;
   }
 
   const ext = extname(filePath).toLowerCase();
-  const langConfig = LANGUAGE_REGISTRY.get(ext);
+  let langConfig = LANGUAGE_REGISTRY.get(ext);
+  if (!langConfig) {
+    ensurePluginsLoaded();
+    langConfig = LANGUAGE_REGISTRY.get(ext);
+  }
 
   if (!langConfig) {
     // If registry is empty or doesn't have this extension, return null
@@ -38,6 +59,11 @@ export async function checkFileWithRegistry(
 
   // Check if language is disabled
   if (isLanguageDisabled(projectRoot, langConfig.name)) {
+    logger.debug('language disabled', {
+      phase: 'setup',
+      file: filePath,
+      language: langConfig.name,
+    });
     return null;
   }
 
@@ -54,17 +80,27 @@ export async function checkFileWithRegistry(
   // Setup command if needed
   let cleanup: (() => void) | undefined;
   let setupContext: Record<string, unknown> | undefined = undefined;
+  const phases: Partial<Record<CheckerPhase, number>> = {};
+  let phaseStart = performance.now();
+  const endPhase = (phase: CheckerPhase): void => {
+    const durationMs = performance.now() - phaseStart;
+    phases[phase] = durationMs;
+    options.onPhase?.(phase, durationMs);
+  };
   if (langConfig.setupCommand) {
-    const setupResult = await langConfig.setupCommand(filePath, projectRoot);
+    const setupResult = await langConfig.setupCommand(filePath, projectRoot, options);
     cleanup = setupResult.cleanup;
     setupContext = setupResult.context as Record<string, unknown> | undefined;
 
     // Check if we should skip checking (e.g., Scala without Bloop)
     if (setupContext?.skipChecking) {
+      logger.debug('checker skipped by setup', { phase: 'setup', file: filePath });
       return null; // Return null to indicate no checking performed
     }
   }
 
+  endPhase('setup');
+
   try {
     // Build command arguments
     const buildResult = langConfig.buildArgs(filePath, projectRoot, toolCommand, setupContext);
@@ -74,6 +110,7 @@ export async function checkFileWithRegistry(
     let args: string[];
     let timeout: number | undefined;
     let workingDirectory = projectRoot;
+    let stdin: string | undefined;
 
     if (Array.isArray(buildResult)) {
       args = buildResult;
@@ -82,12 +119,19 @@ export async function checkFileWithRegistry(
       args = buildResult.args;
       timeout = buildResult.timeout;
       workingDirectory = buildResult.workingDirectory || projectRoot;
+      stdin = buildResult.stdin;
       // Update result tool if a different tool is being used
       if (buildResult.tool) {
         result.tool = buildResult.tool;
       }
     }
 
+    // An explicit tool path (--tool-path, toolPaths config) beats local installs and PATH
+    const toolPath = options.toolPaths?.[result.tool];
+    if (toolPath) {
+      finalTool = toolPath;
+    }
+
     // Prepend the tool command to the arguments array
     const fullCommand = [finalTool, ...args];
 
@@ -95,19 +139,47 @@ export async function checkFileWithRegistry(
 
     // Run the tool with optional environment from context
     const env = setupContext?.env as Record<string, string> | undefined;
-    const { stdout, stderr, timedOut } = await runCommand(
+    logger.debug('running checker', {
+      phase: 'run',
+      file: filePath,
+      command: fullCommand.join(' '),
+      cwd: workingDirectory,
+    });
+    if (options.verbose) {
+      writeVerbose(result.tool, '→', `${fullCommand.join(' ')} (in ${workingDirectory})`);
+    }
+    const startedAt = Date.now();
+    phaseStart = performance.now();
+    const { stdout, stderr, timedOut, exitCode } = await runCommand(
       fullCommand,
       env,
       workingDirectory,
-      timeout
+      options.timeoutMs ?? timeout,
+      stdin
     );
+    endPhase('run');
+    if (options.verbose) {
+      const output = stdout.trim() ? stdout : `(no output, exit code ${exitCode ?? 'unknown'})`;
+      writeVerbose(result.tool, '←', output);
+      if (stderr.trim()) {
+        writeVerbose(result.tool, '', stderr);
+      }
+    }
 
     if (timedOut) {
       result.timedOut = true;
+      result.command = fullCommand.join(' ');
+      logger.warn('checker timed out', {
+        phase: 'run',
+        file: filePath,
+        command: result.command,
+        duration_ms: Date.now() - startedAt,
+      });
       return result;
     }
 
     // Parse output into diagnostics (pass context for tool-specific parsing)
+    phaseStart = performance.now();
     result.diagnostics = langConfig.parseOutput(
       stdout,
       stderr,
@@ -115,11 +187,30 @@ export async function checkFileWithRegistry(
       projectRoot,
       setupContext
     );
+    endPhase('parse');
+    if (options.verbose) {
+      const timings = Object.entries(phases)
+        .map(([phase, ms]) => `${phase} ${ms.toFixed(1)}ms`)
+        .join(', ');
+      writeVerbose(result.tool, '', `${timings}; ${result.diagnostics.length} diagnostics parsed`);
+    }
 
+    logger.info('checked file', {
+      phase: 'check',
+      file: filePath,
+      tool: result.tool,
+      diagnostic_count: result.diagnostics.length,
+      duration_ms: Date.now() - startedAt,
+    });
     return result;
-  } catch (_error) {
+  } catch (error) {
     // Tool not available or command failed - return null (no checking performed)
     // This ensures consistent behavior across all languages
+    logger.error('checker failed', {
+      phase: 'check',
+      file: filePath,
+      error: error instanceof Error ? error.message : String(error),
+    });
     return null;
   } finally {
     // Cleanup if needed (can be sync or async)
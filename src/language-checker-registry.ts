@@ -8,7 +8,7 @@
 import { existsSync } from 'fs';
 import { join, relative } from 'path';
 import { homedir } from 'os';
-import type { FileCheckResult } from './file-checker';
+import type { CheckerOptions, FileCheckResult } from './file-checker';
 
 // Registry interface for language checkers
 export interface LanguageConfig {
@@ -26,7 +26,9 @@ export interface LanguageConfig {
     _projectRoot: string,
     _toolCommand: string,
     _context?: unknown
-  ) => { tool?: string; args: string[]; timeout?: number; workingDirectory?: string } | string[];
+  ) =>
+    | { tool?: string; args: string[]; timeout?: number; workingDirectory?: string; stdin?: string }
+    | string[];
   /** Function to parse tool output into diagnostics */
   parseOutput: (
     _stdout: string,
@@ -40,7 +42,8 @@ export interface LanguageConfig {
   /** Optional: additional setup before running command */
   setupCommand?: (
     _file: string,
-    _projectRoot: string
+    _projectRoot: string,
+    _options?: CheckerOptions
   ) => Promise<{ cleanup?: () => void | Promise<void>; context?: unknown }>;
 }
 
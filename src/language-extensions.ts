@@ -3,10 +3,16 @@
  * Single source of truth for supported file extensions and their languages
  */
 
+import { closeSync, openSync, readSync } from 'fs';
+import { extname } from 'path';
+
 export const LANGUAGE_EXTENSIONS = {
   // TypeScript
   typescript: ['.ts', '.tsx', '.mts', '.cts'],
 
+  // JavaScript (checked by tsc with checkJs)
+  javascript: ['.js', '.jsx', '.mjs', '.cjs'],
+
   // Python
   python: ['.py', '.pyi'],
 
@@ -47,6 +53,15 @@ for (const [language, extensions] of Object.entries(LANGUAGE_EXTENSIONS)) {
   }
 }
 
+export type SupportedLanguage = keyof typeof LANGUAGE_EXTENSIONS;
+
+/**
+ * Check if a name is one of the supported language keys (e.g. 'go')
+ */
+export function isSupportedLanguage(language: string): language is SupportedLanguage {
+  return Object.prototype.hasOwnProperty.call(LANGUAGE_EXTENSIONS, language);
+}
+
 // Get all supported extensions as a flat array
 export const ALL_SUPPORTED_EXTENSIONS = Object.values(LANGUAGE_EXTENSIONS).flat();
 
@@ -70,6 +85,66 @@ export function isExtensionSupported(extension: string): boolean {
   return EXTENSION_TO_LANGUAGE.has(extension.toLowerCase());
 }
 
+// Shebang interpreters (version suffixes like python3.12 stripped) for extensionless scripts
+const INTERPRETER_TO_LANGUAGE: Record<string, SupportedLanguage> = {
+  python: 'python',
+  pypy: 'python',
+  node: 'javascript',
+  deno: 'typescript',
+  'ts-node': 'typescript',
+  tsx: 'typescript',
+  php: 'php',
+  lua: 'lua',
+  elixir: 'elixir',
+  scala: 'scala',
+};
+
+/**
+ * Get language from a script's first line, e.g. `#!/usr/bin/env python3`
+ * @returns Language name or null if it isn't a shebang for a supported language
+ */
+export function getLanguageForShebang(firstLine: string): SupportedLanguage | null {
+  if (!firstLine.startsWith('#!')) {
+    return null;
+  }
+  // Skip `env` and its flags (#!/usr/bin/env -S deno run) to reach the interpreter
+  const words = firstLine.slice(2).trim().split(/\s+/);
+  let index = 0;
+  if (words[0]?.endsWith('/env') || words[0] === 'env') {
+    index++;
+    while (words[index]?.startsWith('-')) {
+      index++;
+    }
+  }
+  const interpreter = (words[index] ?? '').split('/').pop()?.replace(/[\d.]+$/, '') ?? '';
+  return INTERPRETER_TO_LANGUAGE[interpreter] ?? null;
+}
+
+/**
+ * Get language for a file: from its extension, or from the shebang line of
+ * a file without one (bin/migrate, scripts/deploy)
+ * @returns Language name or null if not supported
+ */
+export function detectLanguage(filePath: string): SupportedLanguage | null {
+  const extension = extname(filePath);
+  if (extension) {
+    return getLanguageForExtension(extension);
+  }
+  let fd: number | undefined;
+  try {
+    fd = openSync(filePath, 'r');
+    const buffer = Buffer.alloc(256);
+    const length = readSync(fd, buffer, 0, buffer.length, 0);
+    return getLanguageForShebang(buffer.toString('utf8', 0, length).split('\n')[0] ?? '');
+  } catch {
+    return null;
+  } finally {
+    if (fd !== undefined) {
+      closeSync(fd);
+    }
+  }
+}
+
 /**
  * Get all extensions for a specific language
  * @param language - Language name
@@ -34,7 +34,8 @@ export interface ShellIntegrationOutput {
  */
 export function formatShellIntegrationOutput(
   diagnostics: ShellDiagnostic[],
-  isHook = false
+  isHook = false,
+  maxDiagnosticsToShow = 5
 ): ShellIntegrationOutput {
   if (diagnostics.length === 0) {
     return {
@@ -56,9 +57,8 @@ export function formatShellIntegrationOutput(
     }
   }
 
-  // Build detailed diagnostics (show first 5 items)
+  // Build detailed diagnostics (show first 5 items by default)
   const detailedLines: string[] = [];
-  const maxDiagnosticsToShow = 5;
 
   for (let i = 0; i < Math.min(diagnostics.length, maxDiagnosticsToShow); i++) {
     const diag = diagnostics[i];
@@ -69,6 +69,9 @@ export function formatShellIntegrationOutput(
     detailedLines.push(
       `  ${severityIcon} ${diag.file}:${diag.line}:${diag.column}${code}: ${diag.message}`
     );
+    if (diag.snippet) {
+      detailedLines.push(...diag.snippet.split('\n').map((line) => `    ${line}`));
+    }
   }
 
   // Build visible summary
@@ -110,7 +113,11 @@ export function writeShellIntegrationOutput(output: ShellIntegrationOutput): voi
 /**
  * Convert diagnostics and write shell integration output
  */
-export function outputDiagnostics(diagnostics: ShellDiagnostic[], isHook = false): void {
-  const output = formatShellIntegrationOutput(diagnostics, isHook);
+export function outputDiagnostics(
+  diagnostics: ShellDiagnostic[],
+  isHook = false,
+  maxDiagnosticsToShow?: number
+): void {
+  const output = formatShellIntegrationOutput(diagnostics, isHook, maxDiagnosticsToShow);
   writeShellIntegrationOutput(output);
 }
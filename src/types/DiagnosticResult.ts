@@ -4,4 +4,5 @@ export interface DiagnosticResult {
   column: number;
   message: string;
   severity: 'error' | 'warning' | 'info';
+  code?: string;
 }
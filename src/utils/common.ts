@@ -5,9 +5,13 @@
 
 import { spawn } from 'bun';
 import { existsSync, readFileSync } from 'fs';
-import { dirname, join } from 'path';
+import { dirname, join, relative, sep } from 'path';
 import { homedir } from 'os';
 
+// Commands still running, so a signal can stop them instead of orphaning them
+const runningProcesses = new Set<ReturnType<typeof spawn>>();
+let stopping = false;
+
 /**
  * Execute a command safely and wait for it to exit to prevent zombie processes
  */
@@ -20,17 +24,52 @@ export async function execCommand(
     stderr: 'pipe',
     ...options,
   });
+  runningProcesses.add(proc);
+
+  try {
+    // Read streams in parallel
+    const [stdout, stderr] = await Promise.all([
+      new Response(proc.stdout).text(),
+      new Response(proc.stderr).text(),
+    ]);
+
+    // CRITICAL: Wait for process to exit to prevent zombies
+    const exitCode = await proc.exited;
+
+    if (stopping) {
+      // Never resolve: output of a killed command must not be reported as a clean check
+      await new Promise<never>(() => {});
+    }
+    return { stdout: stdout.trim(), stderr: stderr.trim(), exitCode };
+  } finally {
+    runningProcesses.delete(proc);
+  }
+}
 
-  // Read streams in parallel
-  const [stdout, stderr] = await Promise.all([
-    new Response(proc.stdout).text(),
-    new Response(proc.stderr).text(),
-  ]);
+/**
+ * Stop every running command: SIGTERM first, then SIGKILL for any still
+ * running after deadlineMs. Commands started by execCommand no longer
+ * return once this has been called.
+ */
+export async function stopRunningProcesses(deadlineMs: number): Promise<void> {
+  stopping = true;
+  const procs = [...runningProcesses];
+  for (const proc of procs) {
+    proc.kill('SIGTERM');
+  }
 
-  // CRITICAL: Wait for process to exit to prevent zombies
-  const exitCode = await proc.exited;
+  let timer: NodeJS.Timeout | undefined;
+  const deadline = new Promise<void>((resolve) => {
+    timer = setTimeout(resolve, deadlineMs);
+  });
+  await Promise.race([Promise.all(procs.map((proc) => proc.exited)), deadline]);
+  clearTimeout(timer);
 
-  return { stdout: stdout.trim(), stderr: stderr.trim(), exitCode };
+  for (const proc of procs) {
+    if (proc.exitCode === null && proc.signalCode === null) {
+      proc.kill('SIGKILL');
+    }
+  }
 }
 
 /**
@@ -51,14 +90,17 @@ export async function runCommand(
   args: string[],
   env?: Record<string, string>,
   cwd?: string,
-  timeoutMs?: number // Optional timeout parameter
+  timeoutMs?: number, // Optional timeout parameter
+  input?: string // Written to the command's stdin
 ): Promise<{ stdout: string; stderr: string; timedOut: boolean; exitCode?: number }> {
   const actualTimeout = timeoutMs ?? 30000; // Default 30 second timeout
 
-  // Set up timeout
+  // Set up timeout - aborting kills the process so it doesn't outlive the check
+  const controller = new AbortController();
   let timeoutId: NodeJS.Timeout | null = null;
   const timeoutPromise = new Promise<never>((_, reject) => {
     timeoutId = setTimeout(() => {
+      controller.abort();
       reject(new Error('Command timed out'));
     }, actualTimeout);
   });
@@ -68,6 +110,8 @@ export async function runCommand(
     const resultPromise = execCommand(args, {
       env: env ? { ...process.env, ...env } : process.env,
       cwd: cwd || process.cwd(),
+      signal: controller.signal,
+      ...(input !== undefined ? { stdin: Buffer.from(input) } : {}),
     });
 
     const result = await Promise.race([resultPromise, timeoutPromise]);
@@ -87,13 +131,25 @@ export async function runCommand(
   }
 }
 
+/**
+ * Path of the global LSP config, overridable with CLAUDE_LSP_CONFIG. HOME
+ * (USERPROFILE on Windows) is read on every call so tests can point it elsewhere.
+ */
+export function getConfigPath(): string {
+  if (process.env.CLAUDE_LSP_CONFIG) {
+    return process.env.CLAUDE_LSP_CONFIG;
+  }
+  const homeDir = process.env.HOME || process.env.USERPROFILE || homedir();
+  return join(homeDir, '.claude', 'lsp-config.json');
+}
+
 /**
  * Helper function to read global LSP config
  * Consolidated from file-checker.ts and generic-checker.ts
  */
 export function readLspConfig(): Record<string, unknown> {
   // Only use global config
-  const globalConfigPath = join(homedir(), '.claude', 'lsp-config.json');
+  const globalConfigPath = getConfigPath();
 
   if (existsSync(globalConfigPath)) {
     try {
@@ -182,3 +238,55 @@ export function findProjectRoot(filePath: string): string {
   // Return the file's directory if no project root found
   return dirname(filePath);
 }
+
+function gitignorePatternMatches(pattern: string, path: string): boolean {
+  const dirOnly = pattern.endsWith('/');
+  const trimmed = pattern.replace(/\/+$/, '');
+  // A slash anywhere but the end anchors the pattern to the .gitignore's directory
+  const anchored = trimmed.includes('/');
+  const glob = new Bun.Glob(trimmed.replace(/^\//, ''));
+  const segments = path.split('/');
+  // Ignoring a directory ignores everything in it
+  const depth = dirOnly ? segments.length - 1 : segments.length;
+  for (let i = 1; i <= depth; i++) {
+    const candidate = anchored ? segments.slice(0, i).join('/') : (segments[i - 1] ?? '');
+    if (glob.match(candidate)) {
+      return true;
+    }
+  }
+  return false;
+}
+
+/**
+ * Whether a .gitignore in the file's directory or above it, up to the
+ * repository root, ignores it. Patterns are read without git, so projects
+ * outside a repository are covered too; the last matching pattern wins, as
+ * in git, so `!keep.js` re-includes a file.
+ */
+export function isGitignored(filePath: string): boolean {
+  let dir = dirname(filePath);
+  for (;;) {
+    const ignoreFile = join(dir, '.gitignore');
+    if (existsSync(ignoreFile)) {
+      const path = relative(dir, filePath).split(sep).join('/');
+      let ignored: boolean | null = null;
+      for (const rawLine of readFileSync(ignoreFile, 'utf8').split('\n')) {
+        const line = rawLine.trim();
+        if (!line || line.startsWith('#')) continue;
+        const negated = line.startsWith('!');
+        if (gitignorePatternMatches(negated ? line.slice(1) : line, path)) {
+          ignored = !negated;
+        }
+      }
+      // A deeper .gitignore decides before the ones above it
+      if (ignored !== null) {
+        return ignored;
+      }
+    }
+    const parentDir = dirname(dir);
+    if (parentDir === dir || existsSync(join(dir, '.git'))) {
+      return false;
+    }
+    dir = parentDir;
+  }
+}
@@ -0,0 +1,87 @@
+/**
+ * Structured diagnostic logging
+ *
+ * Logging is off unless enabled with --log-level or --log-file, so hook and
+ * check output stay clean. Entries go to stderr or are appended to a file,
+ * either as readable text or as one JSON object per line:
+ *   {"time":"...","level":"info","msg":"checked file","phase":"check","file":"src/a.ts"}
+ */
+
+import { appendFileSync } from 'fs';
+
+export type LogLevel = 'debug' | 'info' | 'warn' | 'error';
+export type LogFormat = 'text' | 'json';
+export type LogFields = Record<string, string | number | boolean | undefined>;
+
+export const LOG_LEVELS: Record<LogLevel, number> = {
+  debug: 10,
+  info: 20,
+  warn: 30,
+  error: 40,
+};
+
+export const LOG_FORMATS: LogFormat[] = ['text', 'json'];
+
+export interface LoggerConfig {
+  level?: LogLevel;
+  file?: string;
+  format?: LogFormat;
+}
+
+// null while logging is disabled
+let active: { level: LogLevel; format: LogFormat; file?: string } | null = null;
+
+/**
+ * Enable logging. Level defaults to info when only a file is given.
+ */
+export function configureLogger(config: LoggerConfig): void {
+  if (!config.level && !config.file) {
+    active = null;
+    return;
+  }
+  active = { level: config.level ?? 'info', format: config.format ?? 'text', file: config.file };
+}
+
+export function isLogLevel(value: string): value is LogLevel {
+  return Object.prototype.hasOwnProperty.call(LOG_LEVELS, value);
+}
+
+function formatText(time: string, level: LogLevel, msg: string, fields: LogFields): string {
+  const pairs: string[] = [];
+  for (const [key, value] of Object.entries(fields)) {
+    if (value === undefined) continue;
+    // Quote values containing spaces so key=value pairs stay parseable
+    const text = typeof value === 'string' && /\s/.test(value) ? JSON.stringify(value) : value;
+    pairs.push(`${key}=${text}`);
+  }
+  return [time, level.toUpperCase(), msg, ...pairs].join(' ');
+}
+
+function write(level: LogLevel, msg: string, fields: LogFields = {}): void {
+  if (!active || LOG_LEVELS[level] < LOG_LEVELS[active.level]) {
+    return;
+  }
+
+  const time = new Date().toISOString();
+  const line =
+    active.format === 'json'
+      ? JSON.stringify({ time, level, msg, ...fields })
+      : formatText(time, level, msg, fields);
+
+  try {
+    if (active.file) {
+      appendFileSync(active.file, line + '\n');
+    } else {
+      process.stderr.write(line + '\n');
+    }
+  } catch {
+    // Logging must never break a check
+  }
+}
+
+export const logger = {
+  debug: (msg: string, fields?: LogFields) => write('debug', msg, fields),
+  info: (msg: string, fields?: LogFields) => write('info', msg, fields),
+  warn: (msg: string, fields?: LogFields) => write('warn', msg, fields),
+  error: (msg: string, fields?: LogFields) => write('error', msg, fields),
+};
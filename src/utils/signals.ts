@@ -0,0 +1,38 @@
+/**
+ * Signal handling for commands that run checkers
+ *
+ * Ctrl-C in a terminal reaches the whole process group, but SIGTERM from a
+ * CI runner or editor only reaches the CLI and would leave tsc, gopls or
+ * cargo running. On SIGINT, SIGTERM or SIGHUP the running checker processes
+ * are stopped (killed after 5s at most) before exiting.
+ */
+
+import { stopRunningProcesses } from './common';
+
+export const SHUTDOWN_DEADLINE_MS = 5000;
+
+// SIGINT exits like an interrupted shell command; SIGTERM is a requested, clean stop
+const EXIT_CODES = { SIGINT: 130, SIGTERM: 0, SIGHUP: 129 } as const;
+
+type HandledSignal = keyof typeof EXIT_CODES;
+
+/**
+ * Install the handlers; returns a function that removes them again
+ */
+export function installSignalHandlers(
+  exit: (_code: number) => void = (code) => process.exit(code)
+): () => void {
+  const handlers = (Object.keys(EXIT_CODES) as HandledSignal[]).map((signal) => {
+    const handler = (): void => {
+      void stopRunningProcesses(SHUTDOWN_DEADLINE_MS).then(() => exit(EXIT_CODES[signal]));
+    };
+    process.once(signal, handler);
+    return { signal, handler };
+  });
+
+  return () => {
+    for (const { signal, handler } of handlers) {
+      process.off(signal, handler);
+    }
+  };
+}
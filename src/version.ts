@@ -0,0 +1,14 @@
+/**
+ * Package metadata shared by commands and output formats
+ */
+
+import { homepage, version } from '../package.json';
+
+// Injected by `bun build --define` in release builds (see build:cli); absent from source runs
+declare const BUILD_COMMIT: string | undefined;
+declare const BUILD_DATE: string | undefined;
+
+export const VERSION: string = version;
+export const HOMEPAGE: string = homepage;
+export const COMMIT: string = typeof BUILD_COMMIT === 'string' ? BUILD_COMMIT : 'unknown';
+export const BUILD_TIME: string = typeof BUILD_DATE === 'string' ? BUILD_DATE : 'unknown';
@@ -0,0 +1,35 @@
+import { describe, test, expect } from 'bun:test';
+import { mkdtempSync, rmSync, writeFileSync } from 'fs';
+import { tmpdir } from 'os';
+import { join } from 'path';
+import { checkEnvVars, readEnvExample } from '../src/checkers/go-env-vars';
+
+describe('Go Environment Variables', () => {
+  test('readEnvExample should list the documented names', () => {
+    const root = mkdtempSync(join(tmpdir(), 'go-env-'));
+    try {
+      expect(readEnvExample(root)).toBeNull();
+      writeFileSync(join(root, '.env.example'), '# Server\nPORT=8080\nexport DATABASE_URL=\n');
+      expect(readEnvExample(root)).toEqual(new Set(['PORT', 'DATABASE_URL']));
+    } finally {
+      rmSync(root, { recursive: true, force: true });
+    }
+  });
+
+  test('checkEnvVars should report undocumented and dynamic reads', () => {
+    const source = [
+      'port := os.Getenv("PORT")',
+      'secret, ok := os.LookupEnv("API_SECRET")',
+      'region := os.Getenv("APP_" + name)',
+      'level := os.Getenv(key)',
+    ].join('\n');
+
+    const diagnostics = checkEnvVars(source, new Set(['PORT']));
+    expect(diagnostics.map((diag) => [diag.line, diag.column, diag.message])).toEqual([
+      [2, 15, 'environment variable API_SECRET is not documented; add it to .env.example'],
+      [3, 11, 'environment variable name is dynamic, analysis skipped'],
+      [4, 10, 'environment variable name is dynamic, analysis skipped'],
+    ]);
+    expect(diagnostics.every((diag) => diag.severity === 'info')).toBe(true);
+  });
+});
@@ -0,0 +1,61 @@
+import { describe, test, expect } from 'bun:test';
+import { checkRegexps, validateRegexp } from '../src/checkers/go-regexps';
+
+describe('Go Regexps', () => {
+  test('validateRegexp should accept RE2 patterns', () => {
+    expect(validateRegexp('^[a-z]+\\d{2,4}$')).toBeNull();
+    expect(validateRegexp('(?i)(?P<user>\\w+)@(?:example|test)\\.com')).toBeNull();
+    expect(validateRegexp('[]a-]*?|[[:alpha:]]+|\\p{Greek}|\\101')).toBeNull();
+    expect(validateRegexp('a{2}|b{1,}')).toBeNull();
+  });
+
+  test('validateRegexp should explain what RE2 rejects', () => {
+    expect(validateRegexp('foo(?=bar)')).toBe(
+      'invalid or unsupported Perl syntax: `(?=` (RE2 has no lookaround)'
+    );
+    expect(validateRegexp('(a)\\1')).toBe(
+      'invalid escape sequence: `\\1` (RE2 has no backreferences)'
+    );
+    expect(validateRegexp('a++')).toBe('invalid nested repetition operator: `++`');
+    expect(validateRegexp('a*??')).toBe('invalid nested repetition operator: `*??`');
+    expect(validateRegexp('*.go')).toBe('missing argument to repetition operator: `*`');
+    expect(validateRegexp('(ab')).toBe('missing closing ): `(ab`');
+    expect(validateRegexp('ab)')).toBe('unexpected ): `ab)`');
+    expect(validateRegexp('[a-z')).toBe('missing closing ]: `[a-z`');
+    expect(validateRegexp('a{3,1}')).toBe('invalid repeat count: `{3,1}`');
+  });
+
+  test('checkRegexps should flag constant patterns passed to regexp functions', () => {
+    const source = [
+      'package main',
+      '',
+      'var email = regexp.MustCompile(`^\\w+@(?!spam)\\w+$`)',
+      'var ok = regexp.MustCompile("^[a-z]+$")',
+      '// regexp.MustCompile(`(`) in a comment',
+      'func match(s string) (bool, error) {',
+      '\treturn regexp.MatchString("a**", s)',
+      '}',
+    ].join('\n');
+
+    expect(checkRegexps(source)).toEqual([
+      {
+        line: 3,
+        column: 13,
+        severity: 'error',
+        message:
+          'regexp.MustCompile panics at runtime: error parsing regexp: ' +
+          'invalid or unsupported Perl syntax: `(?!` (RE2 has no lookaround)',
+        code: 'regexp',
+      },
+      {
+        line: 7,
+        column: 9,
+        severity: 'error',
+        message:
+          'regexp.MatchString returns an error at runtime: error parsing regexp: ' +
+          'invalid nested repetition operator: `**`',
+        code: 'regexp',
+      },
+    ]);
+  });
+});
@@ -0,0 +1,50 @@
+import { describe, test, expect } from 'bun:test';
+import { checkRoutes } from '../src/checkers/go-routes';
+
+describe('Go Routes', () => {
+  test('should flag a path registered twice on the same mux', () => {
+    const source = [
+      'func main() {',
+      '\thttp.HandleFunc("/users", userHandler.GetUsers)',
+      '\thttp.HandleFunc("/users/create", userHandler.CreateUser)',
+      '\tadmin.HandleFunc("/users", adminHandler.GetUsers)',
+      '\thttp.HandleFunc("/users", userHandler.ListUsers)',
+      '}',
+    ].join('\n');
+
+    expect(checkRoutes(source)).toEqual([
+      {
+        line: 5,
+        column: 2,
+        severity: 'warning',
+        message: 'route "/users" is already registered on http at line 2',
+        code: 'routes',
+      },
+    ]);
+  });
+
+  test('should flag gorilla/mux routes shadowed by an earlier template', () => {
+    const source = [
+      'r := mux.NewRouter()',
+      'r.HandleFunc("/users/{id}", getUser).Methods("GET")',
+      'r.HandleFunc("/users/create", createUser).Methods("POST")',
+      'r.HandleFunc("/users/me", getMe).Methods("GET")',
+    ].join('\n');
+
+    const diagnostics = checkRoutes(source);
+    expect(diagnostics).toHaveLength(1);
+    expect(diagnostics[0]?.line).toBe(4);
+    expect(diagnostics[0]?.message).toBe(
+      'route "/users/me" is unreachable: "/users/{id}" at line 2 matches it first'
+    );
+  });
+
+  test('should not treat net/http wildcards as shadowing', () => {
+    const source = [
+      'mux := http.NewServeMux()',
+      'mux.HandleFunc("/users/{id}", getUser)',
+      'mux.HandleFunc("/users/me", getMe)',
+    ].join('\n');
+    expect(checkRoutes(source)).toEqual([]);
+  });
+});
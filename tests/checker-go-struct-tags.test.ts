@@ -0,0 +1,48 @@
+import { describe, test, expect } from 'bun:test';
+import { checkStructTags, validateStructTag } from '../src/checkers/go-struct-tags';
+
+describe('Go Struct Tags', () => {
+  test('validateStructTag should accept conventional tags', () => {
+    expect(validateStructTag('json:"id"')).toBeNull();
+    expect(validateStructTag('json:"name,omitempty" db:"name"')).toBeNull();
+    expect(validateStructTag('example:"http://x/\\"q\\""')).toBeNull();
+    expect(validateStructTag('')).toBeNull();
+  });
+
+  test('validateStructTag should explain malformed tags', () => {
+    expect(validateStructTag('json:id')).toBe('bad syntax for struct tag value');
+    expect(validateStructTag('json')).toBe('bad syntax for struct tag pair');
+    expect(validateStructTag(':"id"')).toBe('bad syntax for struct tag key');
+    expect(validateStructTag('json:"id"db:"id"')).toBe(
+      'key:"value" pairs not separated by spaces'
+    );
+    expect(validateStructTag('json:"id" json:"user_id"')).toBe('repeated tag key "json"');
+  });
+
+  test('checkStructTags should only look at fields of struct types', () => {
+    const source = [
+      'package models',
+      '',
+      'type User struct {',
+      '\tID    int    `json:"id"`',
+      '\tEmail string `json:email` // see `docs`',
+      '\tMeta  struct {',
+      '\t\tURL string `json:"url" example:"http://example.com"`',
+      '\t} `json:meta`',
+      '}',
+      '',
+      'var query = `SELECT 1`',
+    ].join('\n');
+
+    const diagnostics = checkStructTags(source);
+    expect(diagnostics.map((diag) => [diag.line, diag.column])).toEqual([
+      [5, 15],
+      [8, 4],
+    ]);
+    expect(diagnostics[0]).toMatchObject({ severity: 'warning', code: 'structtag' });
+    expect(diagnostics[0]?.message).toBe(
+      'struct field tag `json:email` is not compatible with reflect.StructTag.Get: ' +
+        'bad syntax for struct tag value'
+    );
+  });
+});
@@ -0,0 +1,173 @@
+/**
+ * Unit tests for checker output parsers (no external tools required)
+ */
+
+import { describe, test, expect } from 'bun:test';
+import { mkdirSync, mkdtempSync, rmSync, writeFileSync } from 'fs';
+import { tmpdir } from 'os';
+import { join } from 'path';
+import { detectModMode, goConfig, goFlagsEnv } from '../src/checkers/go';
+import { pythonConfig } from '../src/checkers/python';
+import { rustConfig } from '../src/checkers/rust';
+
+describe('Checker Output Parsers', () => {
+  describe('Python (pyright --outputjson)', () => {
+    const output = JSON.stringify({
+      generalDiagnostics: [
+        {
+          file: '/project/main.py',
+          severity: 'error',
+          message: 'Import "missing_module" could not be resolved',
+          range: { start: { line: 2, character: 7 }, end: { line: 2, character: 21 } },
+          rule: 'reportMissingImports',
+        },
+        {
+          file: '/project/main.py',
+          severity: 'warning',
+          message: 'Variable "x" is not accessed',
+          range: { start: { line: 0, character: 0 }, end: { line: 0, character: 1 } },
+        },
+      ],
+    });
+
+    test('should keep the pyright rule as the diagnostic code', () => {
+      const diagnostics = pythonConfig.parseOutput(output, '', '/project/main.py', '/project');
+
+      expect(diagnostics[0]).toEqual({
+        line: 3,
+        column: 8,
+        severity: 'error',
+        message: 'Import "missing_module" could not be resolved',
+        code: 'reportMissingImports',
+      });
+    });
+
+    test('should omit the code when pyright reports no rule', () => {
+      const diagnostics = pythonConfig.parseOutput(output, '', '/project/main.py', '/project');
+      expect(diagnostics[1]?.code).toBeUndefined();
+    });
+  });
+
+  describe('Rust (rustc --error-format=json)', () => {
+    const rustcMessage = {
+      $message_type: 'diagnostic',
+      message: 'mismatched types',
+      code: { code: 'E0308', explanation: null },
+      level: 'error',
+      spans: [{ file_name: 'src/main.rs', line_start: 4, column_start: 18, is_primary: true }],
+      children: [
+        { level: 'help', message: 'try using a conversion method: `.to_string()`', spans: [] },
+        { level: 'warning', message: 'ignored child', spans: [] },
+      ],
+    };
+
+    test('should keep the rustc error code', () => {
+      const diagnostics = rustConfig.parseOutput(
+        '',
+        JSON.stringify(rustcMessage),
+        '/project/src/main.rs',
+        '/project'
+      );
+
+      expect(diagnostics).toHaveLength(1);
+      expect(diagnostics[0]?.code).toBe('E0308');
+      expect(diagnostics[0]?.line).toBe(4);
+      expect(diagnostics[0]?.column).toBe(18);
+    });
+
+    test('should append help and note children to the message', () => {
+      const [diag] = rustConfig.parseOutput(
+        '',
+        JSON.stringify(rustcMessage),
+        '/project/src/main.rs',
+        '/project'
+      );
+
+      expect(diag?.message).toBe(
+        'mismatched types; help: try using a conversion method: `.to_string()`'
+      );
+    });
+
+    test('should read cargo compiler-message output', () => {
+      const cargoLine = JSON.stringify({ reason: 'compiler-message', message: rustcMessage });
+      const [diag] = rustConfig.parseOutput(cargoLine, '', '/project/src/main.rs', '/project');
+
+      expect(diag?.code).toBe('E0308');
+      expect(diag?.message).toContain('help: try using a conversion method');
+    });
+  });
+
+  describe('Go (go build / go vet)', () => {
+    test('should vet the package of a test file and keep only its diagnostics', () => {
+      const file = '/project/handlers/user_test.go';
+      expect(goConfig.buildArgs(file, '/project', 'go')).toEqual(['vet', '/project/handlers']);
+
+      const stderr = [
+        '# example.com/project/handlers',
+        'vet: handlers/user_test.go:12:3: undefined: newTestServer',
+        'handlers/user.go:4:2: "fmt" imported and not used',
+        'vet: handlers/superuser_test.go:8:1: undefined: fixture',
+      ].join('\n');
+      const diagnostics = goConfig.parseOutput('', stderr, file, '/project');
+      expect(diagnostics).toEqual([
+        { line: 12, column: 3, severity: 'error', message: 'undefined: newTestServer' },
+      ]);
+    });
+
+    test('should leave the struct tags of test files to go vet', () => {
+      const dir = mkdtempSync(join(tmpdir(), 'claude-lsp-go-'));
+      try {
+        const file = join(dir, 'p_test.go');
+        writeFileSync(file, 'package p\n\ntype fixture struct {\n\tName string `json:name`\n}\n');
+        const stderr =
+          'vet: p_test.go:4:2: struct field tag `json:name` not compatible with ' +
+          'reflect.StructTag.Get: bad syntax for struct tag value';
+        const diagnostics = goConfig.parseOutput('', stderr, file, dir);
+        expect(diagnostics).toHaveLength(1);
+        expect(diagnostics[0]?.severity).toBe('error');
+      } finally {
+        rmSync(dir, { recursive: true, force: true });
+      }
+    });
+
+    test('should hint at --build-tags when build constraints exclude the file', () => {
+      const stderr =
+        'package command-line-arguments: build constraints exclude all Go files in /project/db';
+      const [diag] = goConfig.parseOutput('', stderr, '/project/db/pg.go', '/project');
+      expect(diag?.line).toBe(1);
+      expect(diag?.message).toEndWith('Consider re-running with the appropriate --build-tags.');
+    });
+
+    test('should not report C header positions as Go lines', () => {
+      const stderr = '/usr/include/zlib.h:42:10: error: unknown type name "z_off_t"';
+      const [diag] = goConfig.parseOutput('', stderr, '/project/compress.go', '/project');
+      expect(diag).toEqual({
+        line: 1,
+        column: 1,
+        severity: 'error',
+        message: 'cgo: /usr/include/zlib.h:42: error: unknown type name "z_off_t"',
+      });
+    });
+
+    test('should pass build tags through GOFLAGS', () => {
+      expect(goFlagsEnv('/project', { buildTags: ['integration', 'linux'] })?.GOFLAGS).toContain(
+        '-tags=integration,linux'
+      );
+      expect(goFlagsEnv('/project', {})).toBeUndefined();
+    });
+
+    test('should use vendored modules when vendor/modules.txt exists', () => {
+      const root = mkdtempSync(join(tmpdir(), 'go-vendor-'));
+      try {
+        expect(detectModMode(root)).toBeNull();
+        mkdirSync(join(root, 'vendor'));
+        writeFileSync(join(root, 'vendor', 'modules.txt'), '# example.com/dep v1.0.0\n');
+        expect(detectModMode(root)).toBe('vendor');
+        expect(detectModMode(root, { goMod: 'mod' })).toBe('mod');
+        expect(goFlagsEnv(root)?.GOFLAGS).toContain('-mod=vendor');
+      } finally {
+        rmSync(root, { recursive: true, force: true });
+      }
+    });
+  });
+});
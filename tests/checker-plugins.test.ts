@@ -0,0 +1,103 @@
+/**
+ * Unit tests for plugin checkers (plugins are shell scripts in a temp dir)
+ */
+
+import { describe, test, expect, beforeAll, afterAll } from 'bun:test';
+import { chmodSync, mkdtempSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import { LANGUAGE_REGISTRY } from '../src/language-checker-registry';
+import { expandCheckPaths } from '../src/cli/utils/check-paths';
+import { extractFilePaths } from '../src/cli/utils/file-extraction';
+import {
+  ensurePluginsLoaded,
+  loadPlugins,
+  parsePluginDescription,
+  parsePluginOutput,
+} from '../src/checkers/plugins';
+import '../src/checkers/index';
+
+function writePlugin(dir: string, name: string, describeOutput: string): void {
+  const path = join(dir, name);
+  writeFileSync(
+    path,
+    `#!/bin/sh\nread -r request\ncase "$request" in *describe*) echo '${describeOutput}' ;; esac\n`
+  );
+  chmodSync(path, 0o755);
+}
+
+describe('Plugin Checkers', () => {
+  const dir = mkdtempSync(join(tmpdir(), 'claude-lsp-plugins-'));
+
+  beforeAll(() => {
+    writePlugin(dir, 'solidity', '{"name": "Solidity", "extensions": [".sol"]}');
+    writePlugin(dir, 'shadow-go', '{"name": "ShadowGo", "extensions": [".go"]}');
+    writePlugin(dir, 'broken', 'not json');
+    writeFileSync(join(dir, 'README.txt'), 'not executable');
+  });
+
+  afterAll(() => {
+    rmSync(dir, { recursive: true, force: true });
+  });
+
+  test('should register plugins only for extensions without a checker', () => {
+    expect(loadPlugins(dir)).toEqual(['Solidity']);
+    expect(LANGUAGE_REGISTRY.get('.sol')?.name).toBe('Solidity');
+    expect(LANGUAGE_REGISTRY.get('.sol')?.localPaths).toEqual([join(dir, 'solidity')]);
+    expect(LANGUAGE_REGISTRY.get('.go')?.name).toBe('Go');
+  });
+
+  test('should extract and expand files with plugin extensions', () => {
+    writeFileSync(join(dir, 'Token.sol'), '');
+    expect(extractFilePaths({ tool_input: { file_path: '/project/Token.sol' } })).toEqual([
+      '/project/Token.sol',
+    ]);
+    expect(expandCheckPaths([dir])).toEqual([join(dir, 'Token.sol')]);
+  });
+
+  test('should load plugins only once per run', () => {
+    const lazyDir = mkdtempSync(join(tmpdir(), 'claude-lsp-plugins-'));
+    try {
+      writePlugin(lazyDir, 'dhall', '{"name": "Dhall", "extensions": [".dhall"]}');
+      ensurePluginsLoaded(lazyDir);
+      expect(LANGUAGE_REGISTRY.get('.dhall')?.name).toBe('Dhall');
+
+      writePlugin(lazyDir, 'nix', '{"name": "Nix", "extensions": [".nix"]}');
+      ensurePluginsLoaded(lazyDir);
+      expect(LANGUAGE_REGISTRY.has('.nix')).toBe(false);
+    } finally {
+      rmSync(lazyDir, { recursive: true, force: true });
+    }
+  });
+
+  test('should validate describe answers', () => {
+    expect(parsePluginDescription('{"name": "Dhall", "extensions": [".dhall"]}')).toEqual({
+      name: 'Dhall',
+      extensions: ['.dhall'],
+    });
+    expect(parsePluginDescription('{"name": "Dhall", "extensions": ["dhall"]}')).toBeNull();
+    expect(parsePluginDescription('{"extensions": [".dhall"]}')).toBeNull();
+    expect(
+      parsePluginDescription('{"name": "Dhall", "extensions": [".dhall"], "codes": {"1": "E1"}}')
+        ?.codes
+    ).toEqual({ '1': 'E1' });
+  });
+
+  test('should parse diagnostics with defaults and skip malformed entries', () => {
+    const output = JSON.stringify({
+      diagnostics: [
+        { line: 12, column: 5, severity: 'error', message: 'Undeclared identifier', code: '7576' },
+        { line: 3, severity: 'warning', message: 'Unused variable' },
+        { message: 'no line' },
+      ],
+    });
+    expect(parsePluginOutput(output)).toEqual([
+      { line: 12, column: 5, severity: 'error', message: 'Undeclared identifier', code: '7576' },
+      { line: 3, column: 1, severity: 'warning', message: 'Unused variable' },
+    ]);
+    expect(parsePluginOutput('crashed')).toEqual([]);
+    expect(parsePluginOutput(output, { '7576': 'undeclared-identifier' })[0]?.code).toBe(
+      'undeclared-identifier'
+    );
+  });
+});
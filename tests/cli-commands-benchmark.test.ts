@@ -0,0 +1,46 @@
+import { describe, test, expect } from 'bun:test';
+import { formatBenchmark, parseBenchmarkArgs, percentile } from '../src/cli/commands/benchmark';
+
+describe('Benchmark Command', () => {
+  test('should take nearest-rank percentiles', () => {
+    const values = [5, 1, 4, 2, 3, 6, 7, 8, 9, 10];
+    expect(percentile(values, 50)).toBe(5);
+    expect(percentile(values, 95)).toBe(10);
+    expect(percentile(values, 99)).toBe(10);
+    expect(percentile([], 50)).toBe(0);
+  });
+
+  test('should parse iterations, warmup and format', () => {
+    expect(parseBenchmarkArgs(['src/a.ts'])).toEqual({
+      file: 'src/a.ts',
+      options: { iterations: 10, warmup: 1, format: 'text' },
+    });
+    expect(
+      parseBenchmarkArgs(['--iterations', '20', 'src/a.ts', '--warmup=0', '--format', 'json'])
+    ).toEqual({
+      file: 'src/a.ts',
+      options: { iterations: 20, warmup: 0, format: 'json' },
+    });
+  });
+
+  test('should reject invalid values', () => {
+    expect(parseBenchmarkArgs(['a.ts', '--iterations', 'many']).error).toContain('--iterations');
+    expect(parseBenchmarkArgs(['a.ts', '--iterations', '0']).error).toContain('at least 1');
+    expect(parseBenchmarkArgs(['a.ts', '--format', 'xml']).error).toContain('--format');
+  });
+
+  test('should print a row per phase', () => {
+    const timings = { p50: 12.5, p95: 20, p99: 31.4 };
+    const output = formatBenchmark({
+      file: 'src/a.ts',
+      tool: 'tsc',
+      iterations: 10,
+      phases: { setup: timings, run: timings, parse: timings, total: timings },
+    });
+    const lines = output.split('\n');
+    expect(lines[0]).toBe('Benchmark: src/a.ts (tsc), 10 iterations');
+    expect(lines[1]).toBe('Phase       p50 ms    p95 ms    p99 ms');
+    expect(lines[2]).toBe('setup         12.5      20.0      31.4');
+    expect(lines).toHaveLength(6);
+  });
+});
@@ -0,0 +1,41 @@
+import { describe, test, expect } from 'bun:test';
+import { chmodSync, mkdtempSync, readFileSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import { collectResults } from '../src/cli/commands/check';
+import { loadPlugins } from '../src/checkers/plugins';
+
+describe('Check Command', () => {
+  test('--suppress should cover diagnostics beyond the per-file limit', async () => {
+    const dir = mkdtempSync(join(tmpdir(), 'claude-lsp-check-'));
+    try {
+      // A plugin checker reporting an error on each of the file's 25 lines
+      const diagnostics = Array.from({ length: 25 }, (_, index) => ({
+        line: index + 1,
+        severity: 'error',
+        message: `problem ${index + 1}`,
+      }));
+      const plugin = join(dir, 'lines');
+      writeFileSync(
+        plugin,
+        '#!/bin/sh\nread -r request\ncase "$request" in\n' +
+          `*'"method":"describe"'*) echo '{"name": "Lines", "extensions": [".lines"]}' ;;\n` +
+          `*) echo '${JSON.stringify({ diagnostics })}' ;;\nesac\n`
+      );
+      chmodSync(plugin, 0o755);
+      loadPlugins(dir);
+
+      const file = join(dir, 'input.lines');
+      writeFileSync(file, diagnostics.map((diag) => `line ${diag.line}`).join('\n'));
+
+      const results = await collectResults([file], { suppress: true, minSeverity: 1 });
+      expect(results[0]?.diagnostics).toHaveLength(20);
+      const comments = readFileSync(file, 'utf8')
+        .split('\n')
+        .filter((line) => line.includes('claude-lsp-ignore'));
+      expect(comments).toHaveLength(25);
+    } finally {
+      rmSync(dir, { recursive: true, force: true });
+    }
+  });
+});
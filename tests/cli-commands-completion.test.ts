@@ -0,0 +1,36 @@
+import { describe, test, expect } from 'bun:test';
+import { completionScript, COMPLETION_SHELLS } from '../src/cli/commands/completion';
+
+describe('Completion Command', () => {
+  test('should generate a script for every supported shell', () => {
+    for (const shell of COMPLETION_SHELLS) {
+      const script = completionScript(shell);
+      expect(script).not.toBeNull();
+      expect(script).toContain('claude-lsp-cli');
+      expect(script).toContain('--min-severity');
+    }
+  });
+
+  test('should return null for unknown shells', () => {
+    expect(completionScript('tcsh')).toBeNull();
+  });
+
+  test('bash script should register a completion function', () => {
+    const script = completionScript('bash')!;
+    expect(script).toContain('complete -o default -F _claude_lsp_cli claude-lsp-cli');
+    expect(script).toContain('--format) COMPREPLY=($(compgen -W "text json');
+    expect(script).toContain('--language) COMPREPLY=($(compgen -W "typescript javascript');
+  });
+
+  test('zsh script should describe check flags', () => {
+    const script = completionScript('zsh')!;
+    expect(script.startsWith('#compdef claude-lsp-cli')).toBe(true);
+    expect(script).toContain("'*--watch[Re-check files whenever they are saved]'");
+    expect(script).toContain("'*--config[Alternative config file]:file:_files'");
+  });
+
+  test('fish script should complete flag values', () => {
+    const script = completionScript('fish')!;
+    expect(script).toContain("-l log-level -x -a 'debug info warn error'");
+  });
+});
@@ -0,0 +1,53 @@
+import { describe, test, expect, beforeEach, afterEach } from 'bun:test';
+import { mkdirSync, readFileSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import { runConfigInit } from '../src/cli/commands/config-init';
+
+function answers(...values: string[]): (_question: string) => Promise<string> {
+  return async () => values.shift() ?? '';
+}
+
+describe('Config Init Command', () => {
+  let projectDir: string;
+
+  beforeEach(() => {
+    projectDir = join(tmpdir(), `claude-lsp-config-init-${Date.now()}`);
+    mkdirSync(projectDir, { recursive: true });
+    writeFileSync(join(projectDir, '.git'), '');
+  });
+
+  afterEach(() => {
+    rmSync(projectDir, { recursive: true, force: true });
+  });
+
+  test('should write the answers to the project config', async () => {
+    const path = await runConfigInit(
+      { interactive: true, project: true },
+      answers('markdown', 'bogus', 'error', 'vendor/**, *.pb.go', 'n'),
+      projectDir
+    );
+    expect(path).toBe(join(projectDir, '.claude-lsp.json'));
+    expect(JSON.parse(readFileSync(path, 'utf8'))).toEqual({
+      format: 'markdown',
+      minSeverity: 'error',
+      exclude: ['vendor/**', '*.pb.go'],
+    });
+  });
+
+  test('should write defaults without asking and keep existing keys', async () => {
+    const configPath = join(projectDir, '.claude-lsp.json');
+    writeFileSync(configPath, JSON.stringify({ concurrency: 2 }));
+    const ask = async (): Promise<string> => {
+      throw new Error('should not ask');
+    };
+
+    await runConfigInit({ interactive: false, project: true }, ask, projectDir);
+    expect(JSON.parse(readFileSync(configPath, 'utf8'))).toEqual({
+      concurrency: 2,
+      format: 'text',
+      minSeverity: 'warning',
+      exclude: [],
+    });
+  });
+});
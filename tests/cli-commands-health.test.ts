@@ -0,0 +1,45 @@
+import { describe, test, expect, beforeAll, afterAll } from 'bun:test';
+import { mkdirSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import { formatHealthReport, runHealthChecks } from '../src/cli/commands/health';
+
+describe('Health Command', () => {
+  const projectDir = join(tmpdir(), `claude-lsp-health-${Date.now()}`);
+
+  beforeAll(() => {
+    mkdirSync(projectDir, { recursive: true });
+    writeFileSync(join(projectDir, '.git'), '');
+    writeFileSync(
+      join(projectDir, '.claude-lsp.json'),
+      JSON.stringify({ toolPaths: { go: '/nonexistent/bin/go' } })
+    );
+  });
+
+  afterAll(() => {
+    rmSync(projectDir, { recursive: true, force: true });
+  });
+
+  test('should fail unusable tool paths with a hint', async () => {
+    const checks = await runHealthChecks(projectDir);
+    const toolPath = checks.find((check) => check.name.startsWith('Tool path go'));
+    expect(toolPath?.ok).toBe(false);
+    expect(toolPath?.hint).toContain('go not found at /nonexistent/bin/go');
+    expect(checks.find((check) => check.name.startsWith('Temp directory'))?.ok).toBe(true);
+  });
+
+  test('should mark each check and indent hints of failures', () => {
+    const report = formatHealthReport([
+      { name: 'Config files', ok: true, hint: '' },
+      { name: 'Go checker (go)', ok: false, hint: 'Install Go from https://golang.org' },
+    ]);
+    expect(report).toBe(
+      [
+        'Health check:',
+        '  ✓ Config files',
+        '  ✗ Go checker (go)',
+        '      Install Go from https://golang.org',
+      ].join('\n')
+    );
+  });
+});
@@ -0,0 +1,126 @@
+import { describe, test, expect } from 'bun:test';
+import {
+  buildTrend,
+  parseHistoryArgs,
+  parseSince,
+  runHistory,
+  trendPeriod,
+} from '../src/cli/commands/history';
+import { DiagnosticStore } from '../src/cli/utils/history';
+
+const DAY = 86400000;
+
+describe('History Command', () => {
+  describe('parseHistoryArgs', () => {
+    test('should parse list filters', () => {
+      expect(parseHistoryArgs(['list', '--since', '7d', '--file=a.go'], 10 * DAY)).toEqual({
+        args: { subcommand: 'list', since: 3 * DAY, file: 'a.go' },
+      });
+      expect(parseHistoryArgs(['list']).args).toEqual({ subcommand: 'list', since: 0 });
+    });
+
+    test('should require a run ID for show', () => {
+      expect(parseHistoryArgs(['show', 'abc']).args?.id).toBe('abc');
+      expect(parseHistoryArgs(['show']).error).toBe('history show requires a run ID');
+    });
+
+    test('should parse trend grouping and format', () => {
+      expect(parseHistoryArgs(['trend', '--group-by', 'code', '--format=json']).args).toEqual({
+        subcommand: 'trend',
+        since: 0,
+        groupBy: 'code',
+        format: 'json',
+      });
+      expect(parseHistoryArgs(['trend', '--group-by', 'tool']).error).toBe(
+        'Invalid option: --group-by tool'
+      );
+      expect(parseHistoryArgs(['trend', '--file', 'a.go']).error).toBe(
+        'Invalid option: --file a.go'
+      );
+    });
+
+    test('should reject unknown subcommands and options', () => {
+      expect(parseHistoryArgs(['diff', 'abc']).error).toBe('Unknown history command: diff');
+      expect(parseHistoryArgs(['list', '--since', 'soon']).error).toBe(
+        'Invalid option: --since soon'
+      );
+    });
+  });
+
+  test('parseSince should accept durations and dates', () => {
+    expect(parseSince('12h', DAY)).toBe(DAY / 2);
+    expect(parseSince('2024-05-01')).toBe(Date.UTC(2024, 4, 1));
+    expect(parseSince('later')).toBeNull();
+  });
+
+  test('trendPeriod should widen with the range', () => {
+    expect(trendPeriod(0, 7 * DAY)).toBe('day');
+    expect(trendPeriod(0, 60 * DAY)).toBe('week');
+    expect(trendPeriod(0, 365 * DAY)).toBe('month');
+  });
+
+  describe('buildTrend', () => {
+    const counts = [
+      { severity: 'error' as const, code: 'UndeclaredName', n: 2 },
+      { severity: 'warning' as const, n: 1 },
+    ];
+    const may1 = Date.UTC(2024, 4, 1);
+    const checks = [
+      { runId: 'a', startedAt: may1, file: '/project/api/main.go', counts },
+      { runId: 'b', startedAt: may1 + 3600000, file: '/project/api/main.go', counts: [] },
+      { runId: 'b', startedAt: may1 + 3600000, file: '/project/db/db.go', counts },
+      { runId: 'c', startedAt: may1 + DAY, file: '/project/api/main.go', counts },
+    ];
+
+    test("should count each file's last check per period", () => {
+      expect(buildTrend(checks, 'day')).toEqual([
+        { date: '2024-05-01', errors: 2, warnings: 1 },
+        { date: '2024-05-02', errors: 2, warnings: 1 },
+      ]);
+      // 2024-05-01 is a Wednesday; its week starts on Monday 2024-04-29
+      expect(buildTrend(checks, 'week')).toEqual([{ date: '2024-04-29', errors: 4, warnings: 2 }]);
+      expect(buildTrend(checks, 'month')[0]?.date).toBe('2024-05');
+    });
+
+    test('should pivot by package and code', () => {
+      expect(buildTrend(checks, 'day', 'package', '/project')).toEqual([
+        { date: '2024-05-01', group: 'api', errors: 0, warnings: 0 },
+        { date: '2024-05-01', group: 'db', errors: 2, warnings: 1 },
+        { date: '2024-05-02', group: 'api', errors: 2, warnings: 1 },
+      ]);
+      expect(buildTrend(checks, 'month', 'code').map((row) => row.group)).toEqual([
+        '(none)',
+        'UndeclaredName',
+      ]);
+    });
+  });
+
+  test('should list runs and show one by ID prefix', () => {
+    const store = new DiagnosticStore(':memory:');
+    const diagnostics = [{ line: 3, column: 1, severity: 'error' as const, message: 'bad' }];
+    store.recordRun('3f2a9c1e-0000', new Date(Date.UTC(2024, 4, 1)), [
+      { file: '/project/main.go', tool: 'go', diagnostics },
+    ]);
+    store.recordRun('3f2b0000-0000', new Date(Date.UTC(2024, 4, 2)), []);
+
+    const list = runHistory({ subcommand: 'list', since: 0 }, store).output?.split('\n');
+    expect(list?.[0]).toBe('ID        Started (UTC)        Files  Errors  Warnings  Info');
+    expect(list?.[1]).toBe('3f2a9c1e  2024-05-01 00:00:00      1       1         0     0');
+
+    const shown = runHistory({ subcommand: 'show', id: '3f2a9', since: 0 }, store);
+    expect(shown.output).toContain('main.go:3: error bad (go)');
+    expect(runHistory({ subcommand: 'show', id: '3f2', since: 0 }, store).error).toContain(
+      'ambiguous'
+    );
+    expect(runHistory({ subcommand: 'show', id: 'ffff', since: 0 }, store).error).toBe(
+      'No run with ID ffff'
+    );
+
+    const trendArgs = { subcommand: 'trend' as const, since: 0, format: 'json' as const };
+    const trend = runHistory(trendArgs, store, Date.UTC(2024, 4, 3));
+    expect(JSON.parse(trend.output ?? '')).toEqual([
+      { date: '2024-05-01', errors: 1, warnings: 0 },
+    ]);
+    store.close();
+  });
+});
@@ -0,0 +1,47 @@
+import { describe, test, expect } from 'bun:test';
+import { analyzeFile, handleMcpMessage } from '../src/cli/commands/mcp';
+
+describe('MCP Command', () => {
+  test('should answer initialize with the tools capability', async () => {
+    const response = (await handleMcpMessage({
+      jsonrpc: '2.0',
+      id: 1,
+      method: 'initialize',
+      params: { protocolVersion: '2025-03-26' },
+    })) as { result: { protocolVersion: string; capabilities: object } };
+    expect(response.result.protocolVersion).toBe('2025-03-26');
+    expect(response.result.capabilities).toEqual({ tools: {} });
+  });
+
+  test('should list analyze_file and ignore notifications', async () => {
+    const response = (await handleMcpMessage({ jsonrpc: '2.0', id: 2, method: 'tools/list' })) as {
+      result: { tools: Array<{ name: string }> };
+    };
+    expect(response.result.tools.map((tool) => tool.name)).toEqual(['analyze_file']);
+    expect(await handleMcpMessage({ jsonrpc: '2.0', method: 'notifications/initialized' })).toBe(
+      null
+    );
+  });
+
+  test('should report unknown methods and tools', async () => {
+    const unknownMethod = await handleMcpMessage({ jsonrpc: '2.0', id: 3, method: 'nope' });
+    expect(unknownMethod).toMatchObject({ error: { code: -32601 } });
+    const unknownTool = await handleMcpMessage({
+      jsonrpc: '2.0',
+      id: 4,
+      method: 'tools/call',
+      params: { name: 'apply_fix', arguments: {} },
+    });
+    expect(unknownTool).toMatchObject({ result: { isError: true } });
+  });
+
+  test('analyze_file should validate its arguments', async () => {
+    expect((await analyzeFile({})).isError).toBe(true);
+    expect((await analyzeFile({ path: 'a.go', language: 'cobol' })).content[0]?.text).toBe(
+      'Unsupported language: cobol'
+    );
+    expect((await analyzeFile({ path: '/missing/a.ts' })).content[0]?.text).toContain(
+      'was not checked'
+    );
+  });
+});
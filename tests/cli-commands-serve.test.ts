@@ -0,0 +1,78 @@
+import { describe, test, expect } from 'bun:test';
+import { createServeHandler, parseServeArgs } from '../src/cli/commands/serve';
+
+function post(path: string, body: unknown, token?: string, contentType = 'application/json') {
+  return new Request(`http://localhost${path}`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': contentType,
+      ...(token ? { Authorization: `Bearer ${token}` } : {}),
+    },
+    body: typeof body === 'string' ? body : JSON.stringify(body),
+  });
+}
+
+describe('Serve Command', () => {
+  test('should parse port, host and token', () => {
+    expect(parseServeArgs([], {}).options).toEqual({
+      port: 7777,
+      host: '127.0.0.1',
+      token: undefined,
+    });
+    expect(
+      parseServeArgs(['--port', '8080', '--host=0.0.0.0'], { CLAUDE_LSP_SERVER_TOKEN: 's3cret' })
+        .options
+    ).toEqual({ port: 8080, host: '0.0.0.0', token: 's3cret' });
+    expect(parseServeArgs(['--port', 'http'], {}).error).toContain('--port');
+    expect(parseServeArgs(['--server-token=abc=='], {}).options.token).toBe('abc==');
+  });
+
+  test('should report health', async () => {
+    const handler = createServeHandler(undefined);
+    const response = await handler(new Request('http://localhost/health'));
+    expect(response.status).toBe(200);
+    expect(((await response.json()) as { status: string }).status).toBe('ok');
+  });
+
+  test('should expose Prometheus metrics', async () => {
+    const handler = createServeHandler(undefined);
+    const response = await handler(new Request('http://localhost/metrics'));
+    expect(response.headers.get('Content-Type')).toContain('text/plain');
+    expect(await response.text()).toContain('# TYPE claude_lsp_analyses_total counter');
+  });
+
+  test('should show the config the next request uses', async () => {
+    const handler = createServeHandler(undefined);
+    const response = await handler(post('/config/reload', { path: '/missing/src/a.ts' }));
+    expect(response.status).toBe(200);
+    const body = (await response.json()) as { options: object; warnings: string[] };
+    expect(body.options).toBeDefined();
+    expect(Array.isArray(body.warnings)).toBe(true);
+    expect((await handler(post('/config/reload', { path: 42 }))).status).toBe(400);
+  });
+
+  test('should require the bearer token when one is set', async () => {
+    const handler = createServeHandler('s3cret');
+    expect((await handler(new Request('http://localhost/health'))).status).toBe(401);
+    expect((await handler(post('/analyze', { files: ['a.ts'] }, 'wrong'))).status).toBe(401);
+    const response = await handler(post('/analyze', { files: ['/missing/a.ts'] }, 's3cret'));
+    expect(response.status).toBe(200);
+    expect(await response.json()).toEqual([]);
+  });
+
+  test('should reject malformed analyze requests', async () => {
+    const handler = createServeHandler(undefined);
+    expect((await handler(post('/analyze', 'not json'))).status).toBe(400);
+    expect((await handler(post('/analyze', { files: [] }))).status).toBe(400);
+    const badOptions = post('/analyze', { files: ['a.ts'], options: { minSeverity: 'loud' } });
+    expect((await handler(badOptions)).status).toBe(400);
+    const toolPaths = { toolPaths: { go: '/tmp/x' } };
+    const rejected = await handler(post('/analyze', { files: ['a.ts'], options: toolPaths }));
+    expect(rejected.status).toBe(400);
+    expect(((await rejected.json()) as { error: string }).error).toContain('toolPaths');
+    const plain = post('/analyze', { files: ['a.ts'] }, undefined, 'text/plain');
+    expect((await handler(plain)).status).toBe(415);
+    expect((await handler(new Request('http://localhost/analyze'))).status).toBe(405);
+    expect((await handler(new Request('http://localhost/nope'))).status).toBe(404);
+  });
+});
@@ -0,0 +1,32 @@
+import { describe, test, expect } from 'bun:test';
+import { formatVersion, isNewerVersion, versionInfo } from '../src/cli/commands/version';
+import { VERSION } from '../src/version';
+
+describe('Version Command', () => {
+  test('should report the package version and runtime', () => {
+    const info = versionInfo();
+    expect(info.version).toBe(VERSION);
+    expect(info.bun).toBe(Bun.version);
+    // Source runs have no build metadata
+    expect(info.commit).toBe('unknown');
+  });
+
+  test('should print one field per line', () => {
+    const text = formatVersion({
+      version: '4.0.0',
+      commit: 'abc1234',
+      buildDate: '2026-01-01T00:00:00Z',
+      bun: '1.3.0',
+      platform: 'linux-x64',
+    });
+    expect(text.split('\n')[0]).toBe('claude-lsp-cli 4.0.0');
+    expect(text).toContain('commit:   abc1234');
+  });
+
+  test('isNewerVersion should compare SemVer numerically', () => {
+    expect(isNewerVersion('v4.10.0', '4.9.1')).toBe(true);
+    expect(isNewerVersion('4.0.0', '4.0.0')).toBe(false);
+    expect(isNewerVersion('4.0.1-beta.1', '4.0.0')).toBe(true);
+    expect(isNewerVersion('3.9.9', '4.0.0')).toBe(false);
+  });
+});
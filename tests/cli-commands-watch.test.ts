@@ -0,0 +1,27 @@
+import { describe, test, expect } from 'bun:test';
+import { mkdirSync, mkdtempSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import { buildFileDependents } from '../src/cli/commands/watch';
+
+describe('Watch Command', () => {
+  test('buildFileDependents should map go.mod and go.sum to the module files', () => {
+    const dir = mkdtempSync(join(tmpdir(), 'claude-lsp-watch-'));
+    try {
+      mkdirSync(join(dir, 'api'));
+      writeFileSync(join(dir, 'go.mod'), 'module example.com/app\n');
+      const main = join(dir, 'main.go');
+      const handler = join(dir, 'api', 'handler.go');
+      const script = join(dir, 'build.py');
+      for (const file of [main, handler, script]) {
+        writeFileSync(file, '');
+      }
+
+      const dependents = buildFileDependents([main, handler, script]);
+      expect([...dependents.keys()]).toEqual([join(dir, 'go.mod'), join(dir, 'go.sum')]);
+      expect(dependents.get(join(dir, 'go.sum'))).toEqual([main, handler]);
+    } finally {
+      rmSync(dir, { recursive: true, force: true });
+    }
+  });
+});
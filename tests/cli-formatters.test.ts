@@ -0,0 +1,281 @@
+import { describe, test, expect } from 'bun:test';
+import { getFormatter, OUTPUT_FORMATS } from '../src/cli/formatters';
+import { highlightLine } from '../src/cli/formatters/html';
+import type { FileCheckResult } from '../src/file-checker';
+
+const results: FileCheckResult[] = [
+  {
+    file: 'src/index.ts',
+    tool: 'tsc',
+    diagnostics: [
+      {
+        line: 15,
+        column: 7,
+        severity: 'error',
+        message: "Type 'string' is not assignable to type 'number'",
+        code: 'TS2322',
+      },
+      { line: 20, column: 1, severity: 'warning', message: 'Unused variable' },
+    ],
+  },
+  {
+    file: 'main.py',
+    tool: 'uv',
+    diagnostics: [{ line: 3, column: 5, severity: 'error', message: 'Undefined variable' }],
+  },
+];
+
+// A result from a project in a subdirectory of the repository (e.g. a monorepo package)
+const nested: FileCheckResult[] = [
+  {
+    file: 'main.go',
+    tool: 'go',
+    sourcePath: '/work/repo/examples/go-project/main.go',
+    diagnostics: [{ line: 4, column: 2, severity: 'error', message: 'undefined: x' }],
+  },
+];
+
+function withWorkspace<T>(workspace: string, run: () => T): T {
+  const previous = process.env.GITHUB_WORKSPACE;
+  process.env.GITHUB_WORKSPACE = workspace;
+  try {
+    return run();
+  } finally {
+    if (previous === undefined) {
+      delete process.env.GITHUB_WORKSPACE;
+    } else {
+      process.env.GITHUB_WORKSPACE = previous;
+    }
+  }
+}
+
+describe('Output Formatters', () => {
+  test('should list text and json formats', () => {
+    expect(OUTPUT_FORMATS).toContain('text');
+    expect(OUTPUT_FORMATS).toContain('json');
+  });
+
+  test('should return null for unknown formats', () => {
+    expect(getFormatter('xml')).toBeNull();
+  });
+
+  describe('json', () => {
+    const formatter = getFormatter('json')!;
+
+    test('should emit a single top-level array across files', () => {
+      const parsed = JSON.parse(formatter.format(results));
+
+      expect(Array.isArray(parsed)).toBe(true);
+      expect(parsed).toHaveLength(3);
+      expect(parsed[0]).toEqual({
+        file: 'src/index.ts',
+        line: 15,
+        col: 7,
+        severity: 'error',
+        code: 'TS2322',
+        message: "Type 'string' is not assignable to type 'number'",
+        tool: 'tsc',
+      });
+      expect(parsed[2].file).toBe('main.py');
+    });
+
+    test('should use null for missing codes', () => {
+      const parsed = JSON.parse(formatter.format(results));
+      expect(parsed[1].code).toBeNull();
+    });
+
+    test('should include impact scores when set', () => {
+      const scored = [
+        {
+          file: 'a.go',
+          tool: 'go',
+          diagnostics: [
+            { line: 1, column: 1, severity: 'error' as const, message: 'x', impact: 101 },
+          ],
+        },
+      ];
+      expect(JSON.parse(formatter.format(scored))[0].impact).toBe(101);
+      expect(JSON.parse(formatter.format(results))[0].impact).toBeUndefined();
+    });
+
+    test('should label diagnostics with their language when known', () => {
+      const labelled = [{ ...results[0]!, language: 'typescript' as const }];
+      expect(JSON.parse(formatter.format(labelled))[0].language).toBe('typescript');
+      expect(JSON.parse(formatter.format(results))[0].language).toBeUndefined();
+    });
+
+    test('should emit an empty array when there are no diagnostics', () => {
+      expect(JSON.parse(formatter.format([]))).toEqual([]);
+    });
+  });
+
+  describe('github', () => {
+    const formatter = getFormatter('github')!;
+
+    test('should emit one workflow command per diagnostic', () => {
+      const lines = formatter.format(results).split('\n');
+      expect(lines).toEqual([
+        "::error file=src/index.ts,line=15,col=7,title=TS2322::Type 'string' is not assignable to type 'number'",
+        '::warning file=src/index.ts,line=20,col=1::Unused variable',
+        '::error file=main.py,line=3,col=5::Undefined variable',
+      ]);
+    });
+
+    test('should escape newlines and property separators', () => {
+      const output = formatter.format([
+        {
+          file: 'a,b.ts',
+          tool: 'tsc',
+          diagnostics: [{ line: 1, column: 1, severity: 'info', message: '50% done\nnext' }],
+        },
+      ]);
+      expect(output).toBe('::notice file=a%2Cb.ts,line=1,col=1::50%25 done%0Anext');
+    });
+
+    test('should use paths relative to the repository root', () => {
+      const output = withWorkspace('/work/repo', () => formatter.format(nested));
+      expect(output).toBe('::error file=examples/go-project/main.go,line=4,col=2::undefined: x');
+    });
+  });
+
+  describe('markdown', () => {
+    const formatter = getFormatter('markdown')!;
+
+    test('should summarize errors and warnings per file', () => {
+      const output = formatter.format(results);
+      expect(output.startsWith('## Summary\n')).toBe(true);
+      expect(output).toContain('| src/index.ts | 1 | 1 |');
+      expect(output).toContain('| **Total** | 2 | 1 |');
+      expect(output).toContain('### main.py');
+      expect(output).toContain("- ✗ 15:7 TS2322: Type 'string' is not assignable to type 'number'");
+    });
+
+    test('should put snippets in collapsible details blocks', () => {
+      const output = formatter.format([
+        {
+          file: 'main.go',
+          tool: 'go',
+          diagnostics: [
+            {
+              line: 2,
+              column: 1,
+              severity: 'error',
+              message: 'undefined: x',
+              snippet: '```go\n> 2 | x\n```',
+            },
+          ],
+        },
+      ]);
+      expect(output).toContain('<details>\n<summary>✗ 2:1: undefined: x</summary>\n\n```go');
+    });
+
+    test('should report no issues for clean results', () => {
+      expect(formatter.format([])).toBe('## Summary\n\nNo issues found.');
+    });
+  });
+
+  describe('sarif', () => {
+    const formatter = getFormatter('sarif')!;
+
+    test('should produce a single SARIF 2.1.0 run', () => {
+      const log = JSON.parse(formatter.format(results));
+      expect(log.version).toBe('2.1.0');
+      expect(log.runs).toHaveLength(1);
+      expect(log.runs[0].tool.driver.name).toBe('claude-lsp-cli');
+      expect(log.runs[0].results).toHaveLength(3);
+    });
+
+    test('should map codes to rules and locations to regions', () => {
+      const run = JSON.parse(formatter.format(results)).runs[0];
+      expect(run.tool.driver.rules.map((rule: { id: string }) => rule.id)).toEqual(['TS2322']);
+      expect(run.results[0]).toMatchObject({
+        ruleId: 'TS2322',
+        ruleIndex: 0,
+        level: 'error',
+        locations: [
+          {
+            physicalLocation: {
+              artifactLocation: { uri: 'src/index.ts' },
+              region: { startLine: 15, startColumn: 7 },
+            },
+          },
+        ],
+      });
+      expect(run.results[1].ruleId).toBeUndefined();
+      expect(run.results[1].level).toBe('warning');
+    });
+
+    test('should locate artifacts relative to the repository root', () => {
+      const run = withWorkspace('/work/repo', () => JSON.parse(formatter.format(nested)).runs[0]);
+      expect(run.originalUriBaseIds).toEqual({ '%SRCROOT%': { uri: 'file:///work/repo/' } });
+      expect(run.results[0].locations[0].physicalLocation.artifactLocation).toEqual({
+        uri: 'examples/go-project/main.go',
+        uriBaseId: '%SRCROOT%',
+      });
+    });
+  });
+
+  describe('html', () => {
+    const formatter = getFormatter('html')!;
+
+    test('should render a standalone page with a section per file', () => {
+      const output = formatter.format([
+        ...results,
+        { file: 'clean.ts', tool: 'tsc', diagnostics: [] },
+      ]);
+      expect(output.startsWith('<!DOCTYPE html>\n<html lang="en">')).toBe(true);
+      expect(output).not.toMatch(/<script|<link|src="http/);
+      expect(output).toContain('<summary>src/index.ts (2)</summary>');
+      expect(output).toContain('<summary>Clean files (1)</summary>\n<ul>\n<li>clean.ts</li>');
+      expect(output).toContain(`<span class="error">error</span> 15:7 TS2322: Type 'string'`);
+    });
+
+    test('should highlight snippets and escape markup', () => {
+      expect(highlightLine('return "<b>" // if')).toBe(
+        '<span class="kw">return</span> <span class="str">&quot;&lt;b&gt;&quot;</span> ' +
+          '<span class="com">// if</span>'
+      );
+      const output = formatter.format([
+        {
+          file: 'main.go',
+          tool: 'go',
+          diagnostics: [
+            {
+              line: 2,
+              column: 1,
+              severity: 'error',
+              message: 'undefined: x',
+              snippet: '```go\n> 2 | x := 1\n```',
+            },
+          ],
+        },
+      ]);
+      expect(output).toContain('<pre><code>&gt; 2 | x := <span class="num">1</span></code></pre>');
+    });
+
+    test('should report no issues for clean results', () => {
+      expect(formatter.format([])).toContain('<p>No issues found.</p>');
+    });
+  });
+
+  describe('junit', () => {
+    const formatter = getFormatter('junit')!;
+
+    test('should emit a testsuite per file with errors as failures', () => {
+      const output = formatter.format(results);
+      expect(output).toContain('<testsuite name="src/index.ts" tests="2" failures="1">');
+      expect(output).toContain('<testcase name="TS2322: 15:7" classname="src/index.ts">');
+      expect(output).toContain(
+        '<failure message="Type &apos;string&apos; is not assignable to type &apos;number&apos;" type="error">'
+      );
+      expect(output).toContain('<system-out>warning: Unused variable</system-out>');
+      expect(output).toContain('<testcase name="uv: 3:5" classname="main.py">');
+    });
+
+    test('should give clean files a single passing test case', () => {
+      const output = formatter.format([{ file: 'clean.ts', tool: 'tsc', diagnostics: [] }]);
+      expect(output).toContain('<testsuite name="clean.ts" tests="1" failures="0">');
+      expect(output).toContain('<testcase name="tsc" classname="clean.ts"/>');
+    });
+  });
+});
@@ -0,0 +1,154 @@
+import { describe, test, expect, beforeEach, afterEach } from 'bun:test';
+import { mkdirSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import {
+  configToCheckOptions,
+  loadCheckConfig,
+  PROJECT_CONFIG_FILE,
+} from '../src/cli/utils/check-config';
+
+describe('Check Config', () => {
+  describe('configToCheckOptions', () => {
+    test('should map known keys to check options', () => {
+      const warnings: string[] = [];
+      const options = configToCheckOptions(
+        { format: 'json', minSeverity: 'error' },
+        'test.json',
+        (m) => warnings.push(m)
+      );
+
+      expect(options).toEqual({ format: 'json', minSeverity: 1 });
+      expect(warnings).toHaveLength(0);
+    });
+
+    test('should accept numeric minSeverity', () => {
+      expect(configToCheckOptions({ minSeverity: 2 }, 'test.json', () => {})).toEqual({
+        minSeverity: 2,
+      });
+    });
+
+    test('should accept toolPaths as an object of strings', () => {
+      const toolPaths = { go: '/usr/local/go/bin/go' };
+      expect(configToCheckOptions({ toolPaths }, 'test.json', () => {})).toEqual({ toolPaths });
+
+      const warnings: string[] = [];
+      configToCheckOptions({ toolPaths: { go: 1 } }, 'test.json', (m) => warnings.push(m));
+      expect(warnings[0]).toContain('Invalid "toolPaths"');
+    });
+
+    test('should accept excludeCodes as a list of codes', () => {
+      expect(configToCheckOptions({ excludeCodes: ['SA1006'] }, 'test.json', () => {})).toEqual({
+        excludeCodes: ['SA1006'],
+      });
+
+      const warnings: string[] = [];
+      configToCheckOptions({ excludeCodes: 'SA1006' }, 'test.json', (m) => warnings.push(m));
+      expect(warnings[0]).toContain('Invalid "excludeCodes"');
+    });
+
+    test('should accept severityOverrides by code', () => {
+      const severityOverrides = { U1000: 'error', SA4006: 'hint' };
+      expect(configToCheckOptions({ severityOverrides }, 'test.json', () => {})).toEqual({
+        severityOverrides: { U1000: 'error', SA4006: 'info' },
+      });
+
+      const warnings: string[] = [];
+      configToCheckOptions({ severityOverrides: { U1000: 'fatal' } }, 'test.json', (m) =>
+        warnings.push(m)
+      );
+      expect(warnings[0]).toContain('Invalid "severityOverrides"');
+    });
+
+    test('should accept maxFileSize as bytes or a size string', () => {
+      expect(configToCheckOptions({ maxFileSize: '1MB' }, 'test.json', () => {})).toEqual({
+        maxFileSize: 1024 * 1024,
+      });
+      expect(configToCheckOptions({ maxFileSize: 0 }, 'test.json', () => {})).toEqual({
+        maxFileSize: 0,
+      });
+    });
+
+    test('should accept hooks with command lists and a timeout', () => {
+      const hooks = { preCheck: ['go generate ./...'], postCheck: ['true'], timeout: '90s' };
+      expect(configToCheckOptions({ hooks }, 'test.json', () => {})).toEqual({
+        hooks: { preCheck: ['go generate ./...'], postCheck: ['true'], timeoutMs: 90000 },
+      });
+
+      const warnings: string[] = [];
+      configToCheckOptions({ hooks: { preCheck: 'make' } }, 'test.json', (m) => warnings.push(m));
+      configToCheckOptions({ hooks: { pre_analysis: [] } }, 'test.json', (m) => warnings.push(m));
+      expect(warnings).toHaveLength(2);
+      expect(warnings[0]).toContain('Invalid "hooks"');
+    });
+
+    test('should ignore language disable and checkUpdates keys', () => {
+      const warnings: string[] = [];
+      configToCheckOptions(
+        { disable: false, disablePython: true, checkUpdates: true },
+        'test.json',
+        (m) => warnings.push(m)
+      );
+      expect(warnings).toHaveLength(0);
+    });
+
+    test('should warn about unknown keys and invalid values without failing', () => {
+      const warnings: string[] = [];
+      const options = configToCheckOptions(
+        { modelName: 'x', format: 'xml' },
+        'test.json',
+        (m) => warnings.push(m)
+      );
+
+      expect(options).toEqual({});
+      expect(warnings.some((w) => w.includes('Unknown config key "modelName"'))).toBe(true);
+      expect(warnings.some((w) => w.includes('Invalid "format"'))).toBe(true);
+    });
+  });
+
+  describe('loadCheckConfig', () => {
+    const testDir = join(tmpdir(), 'claude-lsp-check-config-test');
+    const projectDir = join(testDir, 'project');
+    const globalConfig = join(testDir, 'global.json');
+    let originalEnv: string | undefined;
+
+    beforeEach(() => {
+      originalEnv = process.env.CLAUDE_LSP_CONFIG;
+      mkdirSync(projectDir, { recursive: true });
+      writeFileSync(join(projectDir, 'package.json'), '{}');
+      process.env.CLAUDE_LSP_CONFIG = globalConfig;
+    });
+
+    afterEach(() => {
+      if (originalEnv === undefined) {
+        delete process.env.CLAUDE_LSP_CONFIG;
+      } else {
+        process.env.CLAUDE_LSP_CONFIG = originalEnv;
+      }
+      rmSync(testDir, { recursive: true, force: true });
+    });
+
+    test('should let the global config override the project config', () => {
+      writeFileSync(
+        join(projectDir, PROJECT_CONFIG_FILE),
+        JSON.stringify({ format: 'json', minSeverity: 'hint' })
+      );
+      writeFileSync(globalConfig, JSON.stringify({ minSeverity: 'error' }));
+
+      expect(loadCheckConfig(projectDir, () => {})).toEqual({ format: 'json', minSeverity: 1 });
+    });
+
+    test('should warn when CLAUDE_LSP_CONFIG points at a missing file', () => {
+      const warnings: string[] = [];
+      loadCheckConfig(projectDir, (m) => warnings.push(m));
+      expect(warnings.some((w) => w.includes('Config file not found'))).toBe(true);
+    });
+
+    test('should warn on malformed JSON', () => {
+      writeFileSync(globalConfig, '{ not json');
+      const warnings: string[] = [];
+      expect(loadCheckConfig(projectDir, (m) => warnings.push(m))).toEqual({});
+      expect(warnings.some((w) => w.includes('Ignoring config'))).toBe(true);
+    });
+  });
+});
@@ -0,0 +1,51 @@
+import { describe, test, expect } from 'bun:test';
+import { mkdtempSync, readFileSync, rmSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import { runHookCommands, withCheckHooks } from '../src/cli/utils/check-hooks';
+
+describe('Check Hooks', () => {
+  test('should run commands in order with the given environment', async () => {
+    expect(await runHookCommands(['true', 'test "$COUNT" = 3'], { COUNT: '3' })).toBeNull();
+  });
+
+  test('should stop at the first failing command with its stderr', async () => {
+    const failure = await runHookCommands(['echo broken >&2; exit 2', 'true'], {});
+    expect(failure).toBe('"echo broken >&2; exit 2" exited with 2\nbroken');
+  });
+
+  test('should fail commands that outlive the timeout', async () => {
+    expect(await runHookCommands(['sleep 5'], {}, 100)).toContain('timed out');
+  });
+
+  test('should not check when a preCheck hook fails', async () => {
+    let checked = false;
+    const hasErrors = await withCheckHooks({ preCheck: ['exit 1'] }, 1, async () => {
+      checked = true;
+      return false;
+    });
+    expect(hasErrors).toBe(true);
+    expect(checked).toBe(false);
+  });
+
+  test('should pass counts to postCheck hooks and keep the result', async () => {
+    const dir = mkdtempSync(join(tmpdir(), 'claude-lsp-hooks-'));
+    const out = join(dir, 'env');
+    try {
+      const postCheck = [
+        `echo "$CLAUDE_LSP_FILE_COUNT $CLAUDE_LSP_DIAGNOSTIC_COUNT $CLAUDE_LSP_EXIT_CODE" > ${out}`,
+        'exit 1',
+      ];
+      const hasErrors = await withCheckHooks({ postCheck }, 1, async (onReport) => {
+        const diag = { line: 1, column: 1, severity: 'error' as const, message: 'x' };
+        onReport([{ file: 'a.ts', tool: 'tsc', diagnostics: [diag, diag] }]);
+        return false;
+      });
+      // A failing postCheck hook only warns
+      expect(hasErrors).toBe(false);
+      expect(readFileSync(out, 'utf8')).toBe('1 2 0\n');
+    } finally {
+      rmSync(dir, { recursive: true, force: true });
+    }
+  });
+});
@@ -0,0 +1,162 @@
+import { describe, test, expect } from 'bun:test';
+import { parseCheckArgs, parseDuration, parseSize } from '../src/cli/utils/check-options';
+
+describe('parseCheckArgs', () => {
+  test('should collect positional arguments as files', () => {
+    const parsed = parseCheckArgs(['a.ts', 'b.py']);
+    expect(parsed.files).toEqual(['a.ts', 'b.py']);
+    expect(parsed.options).toEqual({});
+    expect(parsed.error).toBeUndefined();
+  });
+
+  test('should parse --format with separate and inline values', () => {
+    expect(parseCheckArgs(['--format', 'json', 'a.ts']).options.format).toBe('json');
+    expect(parseCheckArgs(['a.ts', '--format=json']).options.format).toBe('json');
+  });
+
+  test('should reject unknown formats', () => {
+    const parsed = parseCheckArgs(['--format', 'xml', 'a.ts']);
+    expect(parsed.error).toContain('Invalid --format value: xml');
+  });
+
+  test('should reject a missing --format value', () => {
+    expect(parseCheckArgs(['a.ts', '--format']).error).toContain('Invalid --format value');
+  });
+
+  test('should parse numeric and named --min-severity values', () => {
+    expect(parseCheckArgs(['--min-severity', '2', 'a.ts']).options.minSeverity).toBe(2);
+    expect(parseCheckArgs(['--min-severity=error', 'a.ts']).options.minSeverity).toBe(1);
+  });
+
+  test('should reject invalid --min-severity values', () => {
+    expect(parseCheckArgs(['--min-severity', 'fatal']).error).toContain('Invalid --min-severity');
+  });
+
+  test('should parse boolean --watch', () => {
+    const parsed = parseCheckArgs(['--watch', 'a.ts']);
+    expect(parsed.options.watch).toBe(true);
+    expect(parsed.files).toEqual(['a.ts']);
+  });
+
+  test('should parse --max-diagnostics as a positive integer', () => {
+    expect(parseCheckArgs(['--max-diagnostics=50', 'a.ts']).options.maxDiagnostics).toBe(50);
+    expect(parseCheckArgs(['--max-diagnostics', '0']).error).toContain('Invalid --max-diagnostics');
+  });
+
+  test('should default --context-lines to 10 when no count follows', () => {
+    const parsed = parseCheckArgs(['--context-lines', 'a.ts']);
+    expect(parsed.options.contextLines).toBe(10);
+    expect(parsed.files).toEqual(['a.ts']);
+    expect(parseCheckArgs(['--context-lines', '3']).options.contextLines).toBe(3);
+  });
+
+  test('should parse --timeout durations into milliseconds', () => {
+    expect(parseCheckArgs(['--timeout', '90s']).options.timeoutMs).toBe(90000);
+    expect(parseCheckArgs(['--timeout=fast']).error).toContain('Invalid --timeout');
+  });
+
+  test('parseDuration should accept ms, s, m and bare seconds', () => {
+    expect(parseDuration('500ms')).toBe(500);
+    expect(parseDuration('2m')).toBe(120000);
+    expect(parseDuration('45')).toBe(45000);
+    expect(parseDuration('0s')).toBeNull();
+  });
+
+  test('should parse --max-file-size with KB and MB units', () => {
+    expect(parseCheckArgs(['--max-file-size', '2MB']).options.maxFileSize).toBe(2 * 1024 * 1024);
+    expect(parseCheckArgs(['--max-file-size=0']).options.maxFileSize).toBe(0);
+    expect(parseSize('512kb')).toBe(512 * 1024);
+    expect(parseSize('4096')).toBe(4096);
+    expect(parseCheckArgs(['--max-file-size', 'big']).error).toContain('Invalid --max-file-size');
+  });
+
+  test('should collect --tool-path values per tool', () => {
+    const parsed = parseCheckArgs(['--tool-path', 'go=/opt/go/bin/go', '--tool-path=tsc=npx-tsc']);
+    expect(parsed.options.toolPaths).toEqual({ go: '/opt/go/bin/go', tsc: 'npx-tsc' });
+    expect(parseCheckArgs(['--tool-path', '/opt/go/bin/go']).error).toContain('<tool>=<path>');
+  });
+
+  test('should split --build-tags on commas', () => {
+    expect(parseCheckArgs(['--build-tags', 'integration, linux']).options).toEqual({
+      buildTags: ['integration', 'linux'],
+    });
+    expect(parseCheckArgs(['--build-tags=,']).error).toBe(
+      '--build-tags requires comma-separated tags'
+    );
+  });
+
+  test('should accept known Go --mod modes', () => {
+    expect(parseCheckArgs(['--mod', 'vendor']).options).toEqual({ goMod: 'vendor' });
+    expect(parseCheckArgs(['--mod=other']).error).toContain('Use vendor, mod or readonly');
+  });
+
+  test('should collect comma-separated --exclude-code values', () => {
+    const parsed = parseCheckArgs(['--exclude-code', 'SA1006, U1000', '--exclude-code=TS6133']);
+    expect(parsed.options.excludeCodes).toEqual(['SA1006', 'U1000', 'TS6133']);
+    expect(parseCheckArgs(['--exclude-code']).error).toBe(
+      '--exclude-code requires comma-separated codes'
+    );
+  });
+
+  test('should collect repeated --exclude patterns', () => {
+    const parsed = parseCheckArgs(['--exclude', 'vendor/**', '--exclude=*.pb.go', 'a.go']);
+    expect(parsed.options.exclude).toEqual(['vendor/**', '*.pb.go']);
+    expect(parsed.files).toEqual(['a.go']);
+  });
+
+  test('should parse --stdin with a supported --language', () => {
+    const parsed = parseCheckArgs(['--stdin', '--language', 'Go']);
+    expect(parsed.options).toEqual({ stdin: true, language: 'go' });
+    expect(parseCheckArgs(['--language', 'cobol']).error).toContain('Supported languages: ');
+  });
+
+  test('should allow --since-commit with or without a ref', () => {
+    expect(parseCheckArgs(['--since-commit', 'main']).options.sinceCommit).toBe('main');
+    expect(parseCheckArgs(['--since-commit']).options.sinceCommit).toBe('');
+    expect(parseCheckArgs(['--since-commit=main', 'src']).options.sinceCommit).toBe('main');
+  });
+
+  test('should read a path after --since-commit as a file, not a ref', () => {
+    const parsed = parseCheckArgs(['--since-commit', 'src/']);
+    expect(parsed.options.sinceCommit).toBe('');
+    expect(parsed.files).toEqual(['src/']);
+  });
+
+  test('should parse boolean switches', () => {
+    expect(parseCheckArgs(['--pre-commit']).options).toEqual({ preCommit: true });
+    expect(parseCheckArgs(['--ci']).options).toEqual({ ci: true });
+    expect(parseCheckArgs(['--group']).options).toEqual({ group: true });
+    expect(parseCheckArgs(['--include-tests']).options).toEqual({ includeTests: true });
+    expect(parseCheckArgs(['--history']).options).toEqual({ history: true });
+    expect(parseCheckArgs(['--quiet']).options).toEqual({ quiet: true });
+    expect(parseCheckArgs(['--verbose']).options).toEqual({ verbose: true });
+    expect(parseCheckArgs(['--no-color']).options).toEqual({ color: false });
+  });
+
+  test('should parse --blame-since with an optional --author', () => {
+    const parsed = parseCheckArgs(['--blame-since', 'main', '--author', 'me@example.com']);
+    expect(parsed.options).toEqual({ blameSince: 'main', author: 'me@example.com' });
+    expect(parseCheckArgs(['--blame-since']).error).toBe('--blame-since requires a git ref');
+  });
+
+  test('should parse --output-file with an optional --append-output', () => {
+    const parsed = parseCheckArgs(['--output-file', 'report.json', '--append-output']);
+    expect(parsed.options).toEqual({ outputFile: 'report.json', appendOutput: true });
+    expect(parseCheckArgs(['--output-file']).error).toBe('--output-file requires a file path');
+  });
+
+  test('should parse --concurrency as a positive integer', () => {
+    expect(parseCheckArgs(['--concurrency', '8']).options.concurrency).toBe(8);
+    expect(parseCheckArgs(['--concurrency=-1']).error).toContain('Invalid --concurrency');
+  });
+
+  test('should collect --profile kinds with default paths', () => {
+    const parsed = parseCheckArgs(['--profile', 'cpu', '--profile=mem=heap.json']);
+    expect(parsed.options.profile).toEqual({ cpu: 'claude-lsp-cpu-profile.txt', mem: 'heap.json' });
+    expect(parseCheckArgs(['--profile', 'gpu']).error).toContain('Use cpu or mem');
+  });
+
+  test('should reject unknown options', () => {
+    expect(parseCheckArgs(['--bogus', 'a.ts']).error).toBe('Unknown option: --bogus');
+  });
+});
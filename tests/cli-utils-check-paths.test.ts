@@ -0,0 +1,55 @@
+import { describe, test, expect, beforeEach, afterEach } from 'bun:test';
+import { mkdirSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import { expandCheckPaths, readManifest } from '../src/cli/utils/check-paths';
+
+describe('Check Paths', () => {
+  let dir: string;
+
+  beforeEach(() => {
+    dir = join(tmpdir(), `claude-lsp-check-paths-${Date.now()}`);
+    mkdirSync(join(dir, 'nested'), { recursive: true });
+    writeFileSync(join(dir, 'b.go'), 'package handlers\n');
+    writeFileSync(join(dir, 'a.go'), 'package handlers\n');
+    writeFileSync(join(dir, 'notes.txt'), 'not source\n');
+    writeFileSync(join(dir, 'nested', 'c.go'), 'package nested\n');
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+  });
+
+  test('should expand a directory to its supported files in name order', () => {
+    expect(expandCheckPaths([dir])).toEqual([join(dir, 'a.go'), join(dir, 'b.go')]);
+  });
+
+  test('should include extensionless scripts with a supported shebang', () => {
+    writeFileSync(join(dir, 'migrate'), '#!/usr/bin/env python3\nprint("up")\n');
+    writeFileSync(join(dir, 'run'), '#!/bin/sh\necho run\n');
+    expect(expandCheckPaths([dir])).toEqual([
+      join(dir, 'a.go'),
+      join(dir, 'b.go'),
+      join(dir, 'migrate'),
+    ]);
+  });
+
+  test('should pass file paths through unchanged', () => {
+    expect(expandCheckPaths(['missing.ts', join(dir, 'a.go')])).toEqual([
+      'missing.ts',
+      join(dir, 'a.go'),
+    ]);
+  });
+
+  test('should read manifest paths relative to the manifest', () => {
+    const manifest = join(dir, 'paths.txt');
+    writeFileSync(manifest, '# files to check\n  a.go  \n\nnested/c.go\na.go\nmissing.go\n');
+
+    const warnings: string[] = [];
+    const paths = readManifest(manifest, (message) => warnings.push(message));
+
+    expect(paths).toEqual([join(dir, 'a.go'), join(dir, 'nested', 'c.go')]);
+    expect(warnings).toHaveLength(1);
+    expect(warnings[0]).toContain('missing.go');
+  });
+});
@@ -0,0 +1,27 @@
+import { describe, test, expect } from 'bun:test';
+import { ciDefaults, detectCIEnvironment } from '../src/cli/utils/ci-environment';
+
+describe('CI Environment', () => {
+  test('should detect providers before the generic CI variable', () => {
+    expect(detectCIEnvironment({ CI: 'true', GITHUB_ACTIONS: 'true' })).toBe('github');
+    expect(detectCIEnvironment({ CI: 'true', GITLAB_CI: 'true' })).toBe('gitlab');
+    expect(detectCIEnvironment({ JENKINS_URL: 'https://ci.example.com/' })).toBe('jenkins');
+    expect(detectCIEnvironment({ CI: '1' })).toBe('generic');
+  });
+
+  test('should ignore unset and false values', () => {
+    expect(detectCIEnvironment({})).toBeNull();
+    expect(detectCIEnvironment({ CI: 'false', GITHUB_ACTIONS: '' })).toBeNull();
+  });
+
+  test('should pick the native report format per provider', () => {
+    expect(ciDefaults('github').format).toBe('github');
+    expect(ciDefaults('jenkins').format).toBe('junit');
+    expect(ciDefaults('generic')).toEqual({
+      logLevel: 'info',
+      progress: false,
+      color: false,
+      minSeverity: 1,
+    });
+  });
+});
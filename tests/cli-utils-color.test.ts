@@ -0,0 +1,22 @@
+import { describe, test, expect } from 'bun:test';
+import { shouldColorize } from '../src/cli/utils/color';
+
+describe('Color Detection', () => {
+  const tty = { isTTY: true };
+
+  test('should colorize terminals', () => {
+    expect(shouldColorize(tty, {})).toBe(true);
+    expect(shouldColorize(tty, { TERM: 'xterm-256color' })).toBe(true);
+  });
+
+  test('should not colorize pipes and files', () => {
+    expect(shouldColorize({ isTTY: false }, {})).toBe(false);
+    expect(shouldColorize({}, {})).toBe(false);
+  });
+
+  test('should respect NO_COLOR and TERM=dumb', () => {
+    expect(shouldColorize(tty, { NO_COLOR: '1' })).toBe(false);
+    expect(shouldColorize(tty, { NO_COLOR: '' })).toBe(true);
+    expect(shouldColorize(tty, { TERM: 'dumb' })).toBe(false);
+  });
+});
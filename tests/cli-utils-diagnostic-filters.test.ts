@@ -0,0 +1,142 @@
+import { describe, test, expect } from 'bun:test';
+import {
+  parseSeverityLevel,
+  severityLevel,
+  filterByCode,
+  filterByMinSeverity,
+  remapSeverity,
+  truncateDiagnostics,
+  scoreImpact,
+} from '../src/cli/utils/diagnostic-filters';
+import type { FileCheckResult } from '../src/file-checker';
+
+const results: FileCheckResult[] = [
+  {
+    file: 'src/index.ts',
+    tool: 'tsc',
+    diagnostics: [
+      { line: 1, column: 1, severity: 'error', message: 'Type error' },
+      { line: 2, column: 1, severity: 'warning', message: 'Unused variable' },
+      { line: 3, column: 1, severity: 'info', message: 'Consider const' },
+    ],
+  },
+];
+
+describe('Diagnostic Filters', () => {
+  describe('parseSeverityLevel', () => {
+    test('should accept numeric levels 1-4', () => {
+      expect(parseSeverityLevel('1')).toBe(1);
+      expect(parseSeverityLevel('4')).toBe(4);
+    });
+
+    test('should accept named levels case-insensitively', () => {
+      expect(parseSeverityLevel('error')).toBe(1);
+      expect(parseSeverityLevel('Warning')).toBe(2);
+      expect(parseSeverityLevel('information')).toBe(3);
+      expect(parseSeverityLevel('hint')).toBe(4);
+    });
+
+    test('should reject invalid levels', () => {
+      expect(parseSeverityLevel('0')).toBeNull();
+      expect(parseSeverityLevel('5')).toBeNull();
+      expect(parseSeverityLevel('fatal')).toBeNull();
+    });
+  });
+
+  test('severityLevel should map checker severities to LSP levels', () => {
+    expect(severityLevel('error')).toBe(1);
+    expect(severityLevel('warning')).toBe(2);
+    expect(severityLevel('info')).toBe(3);
+  });
+
+  describe('filterByMinSeverity', () => {
+    test('should keep only errors at level 1', () => {
+      const { results: filtered, filteredCount } = filterByMinSeverity(results, 1);
+      expect(filtered[0]?.diagnostics.map((d) => d.severity)).toEqual(['error']);
+      expect(filteredCount).toBe(2);
+    });
+
+    test('should keep warnings and above at level 2', () => {
+      const { results: filtered, filteredCount } = filterByMinSeverity(results, 2);
+      expect(filtered[0]?.diagnostics).toHaveLength(2);
+      expect(filteredCount).toBe(1);
+    });
+
+    test('should keep everything at level 4', () => {
+      const { filteredCount } = filterByMinSeverity(results, 4);
+      expect(filteredCount).toBe(0);
+    });
+
+    test('should not mutate the input results', () => {
+      filterByMinSeverity(results, 1);
+      expect(results[0]?.diagnostics).toHaveLength(3);
+    });
+  });
+
+  test('remapSeverity should replace severities by code before filtering', () => {
+    const coded: FileCheckResult[] = [
+      {
+        file: 'main.go',
+        tool: 'staticcheck',
+        diagnostics: [
+          { line: 1, column: 1, severity: 'warning', message: 'unused', code: 'U1000' },
+          { line: 2, column: 1, severity: 'warning', message: 'never used', code: 'SA4006' },
+          { line: 3, column: 1, severity: 'warning', message: 'no code' },
+        ],
+      },
+    ];
+    const remapped = remapSeverity(coded, { U1000: 'error', SA4006: 'info' });
+    expect(remapped[0]?.diagnostics.map((d) => d.severity)).toEqual(['error', 'info', 'warning']);
+    expect(filterByMinSeverity(remapped, 1).results[0]?.diagnostics).toHaveLength(1);
+    expect(coded[0]?.diagnostics[0]?.severity).toBe('warning');
+  });
+
+  test('filterByCode should drop diagnostics with excluded codes', () => {
+    const coded: FileCheckResult[] = [
+      {
+        file: 'main.go',
+        tool: 'staticcheck',
+        diagnostics: [
+          { line: 1, column: 1, severity: 'warning', message: 'reflect', code: 'SA1006' },
+          { line: 2, column: 1, severity: 'error', message: 'no code' },
+        ],
+      },
+    ];
+    const { results: filtered, filteredCount } = filterByCode(coded, ['SA1006']);
+    expect(filtered[0]?.diagnostics.map((d) => d.message)).toEqual(['no code']);
+    expect(filteredCount).toBe(1);
+  });
+
+  describe('truncateDiagnostics', () => {
+    test('should keep the most severe diagnostics', () => {
+      const { results: truncated, omittedCount } = truncateDiagnostics(results, 2);
+      expect(truncated[0]?.diagnostics.map((d) => d.severity)).toEqual(['error', 'warning']);
+      expect(omittedCount).toBe(1);
+    });
+
+    test('should rank by impact when scores are set', () => {
+      const cascade: FileCheckResult[] = [
+        {
+          file: 'main.go',
+          tool: 'go',
+          diagnostics: [
+            { line: 1, column: 1, severity: 'error', message: 'missing return' },
+            { line: 2, column: 1, severity: 'error', message: 'undefined: User' },
+            { line: 3, column: 1, severity: 'error', message: 'undefined: User' },
+          ],
+        },
+      ];
+      const scored = scoreImpact(cascade);
+      expect(scored[0]?.diagnostics.map((d) => d.impact)).toEqual([100, 101, 101]);
+
+      const { results: truncated } = truncateDiagnostics(scored, 2);
+      expect(truncated[0]?.diagnostics.map((d) => d.line)).toEqual([2, 3]);
+    });
+
+    test('should leave files under the limit untouched', () => {
+      const { results: truncated, omittedCount } = truncateDiagnostics(results, 3);
+      expect(truncated[0]).toBe(results[0]);
+      expect(omittedCount).toBe(0);
+    });
+  });
+});
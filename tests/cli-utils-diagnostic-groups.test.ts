@@ -0,0 +1,83 @@
+import { describe, test, expect } from 'bun:test';
+import { groupByCause, referencedSymbol } from '../src/cli/utils/diagnostic-groups';
+import type { FileCheckResult } from '../src/file-checker';
+
+function undefinedUse(file: string, line: number): FileCheckResult {
+  return {
+    file,
+    tool: 'go',
+    diagnostics: [
+      {
+        line,
+        column: 1,
+        severity: 'error',
+        message: 'undefined: models.User',
+        code: 'UndeclaredName',
+      },
+    ],
+  };
+}
+
+describe('Diagnostic Groups', () => {
+  test('referencedSymbol should read undefined names and quoted identifiers', () => {
+    expect(referencedSymbol('undefined: models.User')).toBe('models.User');
+    expect(referencedSymbol("Cannot find name 'foo'.", 'TS2304')).toBe('foo');
+    expect(referencedSymbol('missing return')).toBeNull();
+  });
+
+  test('referencedSymbol should ignore quoted types outside name-resolution codes', () => {
+    const message = "Type 'string' is not assignable to type 'number'.";
+    expect(referencedSymbol(message, 'TS2322')).toBeNull();
+    expect(referencedSymbol(message)).toBeNull();
+  });
+
+  test('referencedSymbol should read the type of Go type mismatches', () => {
+    expect(
+      referencedSymbol('cannot use id (variable of type models.ID) as string value in argument')
+    ).toBe('models.ID');
+    expect(referencedSymbol('cannot use f(x) (type []byte) as type string in return')).toBe(
+      '[]byte'
+    );
+  });
+
+  test('should keep the first diagnostic of a group with its count', () => {
+    const { results, groupedCount } = groupByCause([
+      undefinedUse('a.go', 3),
+      undefinedUse('a.go', 9),
+      undefinedUse('b.go', 4),
+    ]);
+    expect(groupedCount).toBe(2);
+    expect(results[0]?.diagnostics.map((diag) => diag.message)).toEqual([
+      'undefined: models.User (+2 more in 2 files: a.go:9, b.go:4)',
+    ]);
+    expect(results[1]?.diagnostics).toEqual([]);
+  });
+
+  test('should count only the files of the other locations', () => {
+    const { results } = groupByCause([undefinedUse('a.go', 3), undefinedUse('a.go', 9)]);
+    expect(results[0]?.diagnostics[0]?.message).toBe(
+      'undefined: models.User (+1 more in 1 file: a.go:9)'
+    );
+  });
+
+  test('should point at a root cause when a group spans many files', () => {
+    const files = ['a.go', 'b.go', 'c.go', 'd.go'].map((file) => undefinedUse(file, 1));
+    const { results } = groupByCause(files);
+    expect(results[0]?.diagnostics[0]?.message).toContain('likely a single root cause');
+  });
+
+  test('should leave diagnostics without a symbol or with a unique cause alone', () => {
+    const input: FileCheckResult[] = [
+      {
+        file: 'a.go',
+        tool: 'go',
+        diagnostics: [
+          { line: 1, column: 1, severity: 'error', message: 'missing return' },
+          { line: 2, column: 1, severity: 'error', message: 'missing return' },
+          { line: 3, column: 1, severity: 'error', message: 'undefined: x' },
+        ],
+      },
+    ];
+    expect(groupByCause(input)).toEqual({ results: input, groupedCount: 0 });
+  });
+});
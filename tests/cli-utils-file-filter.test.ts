@@ -0,0 +1,69 @@
+import { describe, test, expect } from 'bun:test';
+import { mkdtempSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import {
+  applyExcludes,
+  applyMaxFileSize,
+  applyTestFilter,
+  formatFileSize,
+  shouldExclude,
+} from '../src/cli/utils/file-filter';
+
+describe('File Filter', () => {
+  const cwd = '/project';
+
+  test('should match directory trees with **', () => {
+    expect(shouldExclude('/project/vendor/github.com/x/y.go', ['vendor/**'], cwd)).toBe(true);
+    expect(shouldExclude('/project/internal/y.go', ['vendor/**'], cwd)).toBe(false);
+  });
+
+  test('should match slash-free patterns against the file name anywhere', () => {
+    expect(shouldExclude('/project/api/v1/user.pb.go', ['*.pb.go'], cwd)).toBe(true);
+    expect(shouldExclude('/project/db/models_generated.go', ['*_generated.go'], cwd)).toBe(true);
+    expect(shouldExclude('/project/db/models.go', ['*_generated.go'], cwd)).toBe(false);
+  });
+
+  test('should resolve relative paths against cwd', () => {
+    expect(shouldExclude('vendor/a.go', ['vendor/**'], cwd)).toBe(true);
+  });
+
+  test('applyExcludes should count skipped files', () => {
+    const { files, skippedCount } = applyExcludes(['a.go', 'a.pb.go', 'b.pb.go'], ['*.pb.go']);
+    expect(files).toEqual(['a.go']);
+    expect(skippedCount).toBe(2);
+  });
+
+  test('applyExcludes should keep everything without patterns', () => {
+    expect(applyExcludes(['a.go'], undefined)).toEqual({ files: ['a.go'], skippedCount: 0 });
+  });
+
+  test('applyTestFilter should skip Go test files unless they are included', () => {
+    const files = ['a.go', 'a_test.go', 'test.go'];
+    expect(applyTestFilter(files, undefined)).toEqual({ files: ['a.go', 'test.go'], testCount: 1 });
+    expect(applyTestFilter(files, true)).toEqual({ files, testCount: 0 });
+  });
+
+  test('applyMaxFileSize should skip larger files with their sizes', () => {
+    const dir = mkdtempSync(join(tmpdir(), 'claude-lsp-size-'));
+    try {
+      const small = join(dir, 'small.go');
+      const large = join(dir, 'large.go');
+      writeFileSync(small, 'package main\n');
+      writeFileSync(large, 'x'.repeat(2048));
+      expect(applyMaxFileSize([small, large], 1024)).toEqual({
+        files: [small],
+        tooLarge: [{ file: large, size: 2048 }],
+      });
+      expect(applyMaxFileSize([small, large], 0).files).toEqual([small, large]);
+    } finally {
+      rmSync(dir, { recursive: true, force: true });
+    }
+  });
+
+  test('formatFileSize should pick a readable unit', () => {
+    expect(formatFileSize(800)).toBe('800 B');
+    expect(formatFileSize(600 * 1024)).toBe('600 KB');
+    expect(formatFileSize(2.1 * 1024 * 1024)).toBe('2.1 MB');
+  });
+});
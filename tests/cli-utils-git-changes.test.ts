@@ -0,0 +1,103 @@
+import { describe, test, expect, beforeAll, afterAll } from 'bun:test';
+import { mkdirSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import {
+  collectChangedFiles,
+  collectStagedFiles,
+  filterByBlame,
+  limitToPaths,
+  parseBlamePorcelain,
+} from '../src/cli/utils/git-changes';
+import type { FileCheckResult } from '../src/file-checker';
+
+function git(cwd: string, ...args: string[]): void {
+  Bun.spawnSync(['git', '-c', 'user.name=test', '-c', 'user.email=test@example.com', ...args], {
+    cwd,
+  });
+}
+
+describe('Git Changes', () => {
+  const repo = join(tmpdir(), `claude-lsp-git-changes-${Date.now()}`);
+
+  beforeAll(() => {
+    mkdirSync(join(repo, 'src'), { recursive: true });
+    writeFileSync(join(repo, 'src', 'old.ts'), 'export const a = 1;\n');
+    git(repo, 'init', '-q');
+    git(repo, 'add', '-A');
+    git(repo, 'commit', '-q', '-m', 'base');
+    git(repo, 'tag', 'base');
+
+    writeFileSync(join(repo, 'src', 'new.ts'), 'export const b = 2;\n');
+    writeFileSync(join(repo, 'README.md'), '# readme\n');
+    git(repo, 'add', '-A');
+    git(repo, 'commit', '-q', '-m', 'change');
+  });
+
+  afterAll(() => {
+    rmSync(repo, { recursive: true, force: true });
+  });
+
+  test('should list supported files changed since the ref', async () => {
+    const { files, error } = await collectChangedFiles('base', repo);
+    expect(error).toBeUndefined();
+    expect(files.map((file) => file.replace(/\\/g, '/'))).toEqual([
+      expect.stringMatching(/src\/new\.ts$/),
+    ]);
+  });
+
+  test('should describe unknown refs', async () => {
+    const { error } = await collectChangedFiles('no-such-ref', repo);
+    expect(error).toContain('unknown git ref "no-such-ref"');
+  });
+
+  test('should list staged supported files', async () => {
+    writeFileSync(join(repo, 'src', 'staged.ts'), 'export const c = 3;\n');
+    writeFileSync(join(repo, 'src', 'unstaged.ts'), 'export const d = 4;\n');
+    git(repo, 'add', 'src/staged.ts');
+
+    const { files, error } = await collectStagedFiles(repo);
+    expect(error).toBeUndefined();
+    expect(files.map((file) => file.replace(/\\/g, '/'))).toEqual([
+      expect.stringMatching(/src\/staged\.ts$/),
+    ]);
+  });
+
+  test('should keep diagnostics on lines the author changed since the ref', async () => {
+    const results: FileCheckResult[] = ['src/old.ts', 'src/new.ts'].map((file) => ({
+      file,
+      tool: 'tsc',
+      diagnostics: [{ line: 1, column: 1, severity: 'error' as const, message: 'x' }],
+    }));
+    const sourcePath = (result: FileCheckResult): string => join(repo, result.file);
+
+    const mine = await filterByBlame(results, sourcePath, 'base', 'test@example.com', repo);
+    expect(mine.filteredCount).toBe(1);
+    expect(mine.results.map((result) => result.diagnostics.length)).toEqual([0, 1]);
+
+    const theirs = await filterByBlame(results, sourcePath, 'base', 'other@example.com', repo);
+    expect(theirs.filteredCount).toBe(2);
+  });
+
+  test('parseBlamePorcelain should carry author details to repeated commits', () => {
+    const hash = 'a'.repeat(40);
+    const output = [
+      `${hash} 1 1 2`,
+      'author Test',
+      'author-mail <test@example.com>',
+      '\tconst a = 1;',
+      `${hash} 2 2`,
+      '\tconst b = 2;',
+    ].join('\n');
+    expect(parseBlamePorcelain(output).get(2)).toEqual({
+      commit: hash,
+      authorEmail: 'test@example.com',
+    });
+  });
+
+  test('limitToPaths should keep files under the given paths', () => {
+    const files = [join(repo, 'src', 'new.ts'), join(repo, 'lib', 'x.ts')];
+    expect(limitToPaths(files, [join(repo, 'src')])).toEqual([join(repo, 'src', 'new.ts')]);
+    expect(limitToPaths(files, [])).toEqual(files);
+  });
+});
@@ -0,0 +1,107 @@
+import { describe, test, expect, beforeEach, afterEach } from 'bun:test';
+import { DiagnosticStore } from '../src/cli/utils/history';
+import type { FileCheckResult } from '../src/file-checker';
+
+function run(line: number, extra: FileCheckResult['diagnostics'] = []): FileCheckResult[] {
+  return [
+    {
+      file: '/project/main.go',
+      tool: 'go',
+      diagnostics: [
+        { line, column: 1, severity: 'error', message: 'undefined: x', code: 'UndeclaredName' },
+        ...extra,
+      ],
+    },
+    { file: '/project/util.go', tool: 'go', diagnostics: [] },
+  ];
+}
+
+describe('DiagnosticStore', () => {
+  let store: DiagnosticStore;
+
+  beforeEach(() => {
+    store = new DiagnosticStore(':memory:');
+  });
+
+  afterEach(() => {
+    store.close();
+  });
+
+  test('should return per-run totals by severity, oldest first', () => {
+    const warning = { line: 9, column: 1, severity: 'warning' as const, message: 'unused' };
+    store.recordRun('b', new Date(2000), run(3));
+    store.recordRun('a', new Date(1000), run(3, [warning]));
+
+    const trend = store.queryTrend(undefined, new Date(0));
+    expect(trend.map((summary) => summary.runId)).toEqual(['a', 'b']);
+    expect(trend[0]).toEqual({
+      runId: 'a',
+      startedAt: 1000,
+      files: 2,
+      errors: 1,
+      warnings: 1,
+      infos: 0,
+    });
+    expect(store.queryTrend(undefined, new Date(1500)).map((summary) => summary.runId)).toEqual([
+      'b',
+    ]);
+  });
+
+  test('should limit a file trend to the runs that checked it', () => {
+    store.recordRun('a', new Date(1000), run(3));
+    const other: FileCheckResult = { file: '/project/other.go', tool: 'go', diagnostics: [] };
+    store.recordRun('b', new Date(2000), [other]);
+
+    const trend = store.queryTrend('/project/util.go', new Date(0));
+    expect(trend).toHaveLength(1);
+    expect(trend[0]).toMatchObject({ runId: 'a', files: 1, errors: 0 });
+  });
+
+  test('should return every file check with its diagnostic counts', () => {
+    store.recordRun('a', new Date(1000), run(3));
+    store.recordRun('b', new Date(2000), run(4));
+
+    const checks = store.fileChecks(new Date(1500));
+    expect(checks).toEqual([
+      {
+        runId: 'b',
+        startedAt: 2000,
+        file: '/project/main.go',
+        counts: [{ severity: 'error', code: 'UndeclaredName', n: 1 }],
+      },
+      { runId: 'b', startedAt: 2000, file: '/project/util.go', counts: [] },
+    ]);
+  });
+
+  test('should record the source path rather than the project-relative one', () => {
+    const nested: FileCheckResult = {
+      file: 'src/a.ts',
+      sourcePath: '/project/src/a.ts',
+      tool: 'tsc',
+      diagnostics: [],
+    };
+    store.recordRun('a', new Date(1000), [nested]);
+    expect(store.fileChecks(new Date(0)).map((check) => check.file)).toEqual([
+      '/project/src/a.ts',
+    ]);
+  });
+
+  test('should find issues present in every recent run even when their line moves', () => {
+    const fixed = { line: 5, column: 1, severity: 'warning' as const, message: 'fixed later' };
+    store.recordRun('a', new Date(1000), run(3, [fixed]));
+    store.recordRun('b', new Date(2000), run(4));
+    store.recordRun('c', new Date(3000), run(7));
+
+    expect(store.persistentIssues(3)).toEqual([
+      {
+        file: '/project/main.go',
+        line: 7,
+        severity: 'error',
+        code: 'UndeclaredName',
+        message: 'undefined: x',
+        tool: 'go',
+      },
+    ]);
+    expect(store.persistentIssues(4)).toEqual([]);
+  });
+});
@@ -0,0 +1,40 @@
+import { describe, test, expect } from 'bun:test';
+import type { FileCheckResult } from '../src/file-checker';
+import { MetricsCollector } from '../src/cli/utils/metrics';
+
+describe('Metrics Collector', () => {
+  const results: FileCheckResult[] = [
+    {
+      file: 'src/a.ts',
+      tool: 'tsc',
+      diagnostics: [
+        { line: 1, column: 1, severity: 'error', message: 'x' },
+        { line: 2, column: 1, severity: 'warning', message: 'y' },
+      ],
+    },
+    { file: 'contracts/Token.sol', tool: 'solidity', diagnostics: [] },
+  ];
+
+  test('should count files by language and diagnostics by severity', () => {
+    const metrics = new MetricsCollector();
+    metrics.recordAnalysis(results, 0.3);
+    const output = metrics.render();
+    expect(output).toContain('claude_lsp_analyses_total{language="typescript"} 1');
+    expect(output).toContain('claude_lsp_analyses_total{language="other"} 1');
+    expect(output).toContain('claude_lsp_analyses_total{language="go"} 0');
+    expect(output).toContain('claude_lsp_diagnostics_total{severity="error"} 1');
+    expect(output).toContain('claude_lsp_diagnostics_total{severity="info"} 0');
+  });
+
+  test('should keep histogram buckets cumulative', () => {
+    const metrics = new MetricsCollector();
+    metrics.recordAnalysis([], 0.3);
+    metrics.recordAnalysis([], 3);
+    const output = metrics.render();
+    expect(output).toContain('claude_lsp_analyze_duration_seconds_bucket{le="0.25"} 0');
+    expect(output).toContain('claude_lsp_analyze_duration_seconds_bucket{le="0.5"} 1');
+    expect(output).toContain('claude_lsp_analyze_duration_seconds_bucket{le="5"} 2');
+    expect(output).toContain('claude_lsp_analyze_duration_seconds_bucket{le="+Inf"} 2');
+    expect(output).toContain('claude_lsp_analyze_duration_seconds_count 2');
+  });
+});
@@ -0,0 +1,34 @@
+import { describe, test, expect } from 'bun:test';
+import { startHeapLogging, topFunctions } from '../src/cli/utils/profiler';
+import type { LogFields } from '../src/utils/logger';
+
+describe('Profiler', () => {
+  test('topFunctions should keep the leading rows of a function report', () => {
+    const report = [
+      'Sampling rate: 1000.000000 microseconds. Total samples: 120',
+      "Top functions as <numSamples  'functionName#hash:sourceID'>",
+      "    60    'parseOutput#AbCdEf:3'",
+      "    30    'checkFile#GhIjKl:2'",
+      "    10    'extractContext#MnOpQr:4'",
+    ].join('\n');
+    expect(topFunctions(report, 2)).toEqual([
+      "60    'parseOutput#AbCdEf:3'",
+      "30    'checkFile#GhIjKl:2'",
+    ]);
+    expect(topFunctions('')).toEqual([]);
+  });
+
+  test('startHeapLogging should log memory usage until stopped', async () => {
+    const logged: LogFields[] = [];
+    const stop = startHeapLogging(5, (fields) => logged.push(fields));
+    await Bun.sleep(30);
+    stop();
+    const count = logged.length;
+    await Bun.sleep(20);
+
+    expect(count).toBeGreaterThan(0);
+    expect(logged).toHaveLength(count);
+    expect(logged[0]).toMatchObject({ phase: 'profile' });
+    expect(logged[0]?.heapUsed).toBeGreaterThan(0);
+  });
+});
@@ -0,0 +1,52 @@
+import { describe, test, expect } from 'bun:test';
+import { createProgressReporter } from '../src/cli/utils/progress';
+
+function fakeStream(isTTY: boolean): { stream: NodeJS.WriteStream; output: string[] } {
+  const output: string[] = [];
+  const stream = {
+    isTTY,
+    write: (chunk: string) => {
+      output.push(chunk);
+      return true;
+    },
+  } as unknown as NodeJS.WriteStream;
+  return { stream, output };
+}
+
+describe('Progress Reporter', () => {
+  test('should stay silent off a TTY unless progress is requested', () => {
+    const { stream, output } = fakeStream(false);
+    const progress = createProgressReporter(undefined, stream);
+    progress.start('Checking 3 files');
+    progress.done();
+    expect(output).toHaveLength(0);
+  });
+
+  test('should log one timestamped line per phase when requested off a TTY', () => {
+    const { stream, output } = fakeStream(false);
+    const progress = createProgressReporter(true, stream);
+    progress.start('Checking 2 files');
+    progress.update('Checked 1/2 files');
+    progress.done();
+
+    expect(output).toHaveLength(3);
+    expect(output[0]).toMatch(/^\[\d{2}:\d{2}:\d{2}\] Checking 2 files\n$/);
+    expect(output[2]).toMatch(/Done in \d+\.\ds\n$/);
+  });
+
+  test('should clear the spinner line when done on a TTY', () => {
+    const { stream, output } = fakeStream(true);
+    const progress = createProgressReporter(undefined, stream);
+    progress.start('Checking a.ts');
+    progress.done();
+
+    expect(output[0]).toContain('Checking a.ts');
+    expect(output[output.length - 1]).toBe('\r\x1b[K');
+  });
+
+  test('should never report when disabled', () => {
+    const { stream, output } = fakeStream(true);
+    createProgressReporter(false, stream).start('Checking a.ts');
+    expect(output).toHaveLength(0);
+  });
+});
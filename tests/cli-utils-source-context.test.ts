@@ -0,0 +1,30 @@
+import { describe, test, expect } from 'bun:test';
+import { extractContext, fenceSnippet } from '../src/cli/utils/source-context';
+
+const source = ['line one', 'line two', 'line three', 'line four', 'line five'];
+
+describe('Source Context', () => {
+  test('should include surrounding lines with line numbers', () => {
+    expect(extractContext(source, 3, 3, 1)).toBe(
+      ['  2 | line two', '> 3 | line three', '  4 | line four'].join('\n')
+    );
+  });
+
+  test('should clamp context to the start and end of the file', () => {
+    const snippet = extractContext(source, 1, 1, 10).split('\n');
+    expect(snippet).toHaveLength(5);
+    expect(snippet[0]).toBe('> 1 | line one');
+  });
+
+  test('should mark every line of a multi-line range', () => {
+    const marked = extractContext(source, 2, 4, 0)
+      .split('\n')
+      .filter((line) => line.startsWith('>'));
+    expect(marked).toHaveLength(3);
+  });
+
+  test('should fence snippets with the file language', () => {
+    expect(fenceSnippet('main.go', 'x')).toBe('```go\nx\n```');
+    expect(fenceSnippet('notes.unknown', 'x')).toBe('```\nx\n```');
+  });
+});
@@ -0,0 +1,92 @@
+import { describe, test, expect } from 'bun:test';
+import { mkdtempSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import {
+  commentPrefix,
+  filterSuppressed,
+  findSuppressions,
+  insertSuppressions,
+} from '../src/cli/utils/suppressions';
+import type { Diagnostic } from '../src/file-checker';
+
+const typeError: Diagnostic = {
+  line: 2,
+  column: 7,
+  severity: 'error',
+  message: "Type 'string' is not assignable to type 'number'.",
+  code: 'TS2322',
+};
+
+describe('Suppressions', () => {
+  test('should find comments in each language style', () => {
+    const found = findSuppressions([
+      '// claude-lsp-ignore: TS2322',
+      '  # claude-lsp-ignore',
+      '-- claude-lsp-ignore: undefined global',
+    ]);
+    expect(found.map((s) => [s.targetLine, s.text])).toEqual([
+      [2, 'TS2322'],
+      [3, ''],
+      [4, 'undefined global'],
+    ]);
+  });
+
+  test('should filter diagnostics matching by code', () => {
+    const source = ['// claude-lsp-ignore: TS2322 Type string', 'const port: number = "80";'];
+    const result = filterSuppressed([typeError], source);
+    expect(result.diagnostics).toHaveLength(0);
+    expect(result.suppressedCount).toBe(1);
+    expect(result.stale).toHaveLength(0);
+  });
+
+  test('should match by message prefix when the tool has no codes', () => {
+    const diag: Diagnostic = { line: 2, column: 1, severity: 'error', message: 'undefined: x' };
+    const source = ['// claude-lsp-ignore: undefined: x', 'fmt.Println(x)'];
+    expect(filterSuppressed([diag], source).diagnostics).toHaveLength(0);
+  });
+
+  test('should report comments that match nothing as stale', () => {
+    const source = ['// claude-lsp-ignore: TS9999', 'const port: number = "80";'];
+    const result = filterSuppressed([typeError], source);
+    expect(result.diagnostics).toHaveLength(1);
+    expect(result.stale.map((s) => s.commentLine)).toEqual([1]);
+  });
+
+  test('should insert indented comments above diagnostics', () => {
+    const source = ['function f() {', '  const port: number = "80";', '}'];
+    const updated = insertSuppressions(source, [typeError], 'src/index.ts');
+    expect(updated[1]).toBe(
+      "  // claude-lsp-ignore: TS2322 Type 'string' is not assignable to type 'number'."
+    );
+    expect(filterSuppressed([{ ...typeError, line: 3 }], updated).diagnostics).toHaveLength(0);
+  });
+
+  test('should list every code on a line in one comment', () => {
+    const unused: Diagnostic = { ...typeError, message: "'port' is declared", code: 'TS6133' };
+    const source = ['', 'const port: number = "80";'];
+    const updated = insertSuppressions(source, [typeError, unused, typeError], 'src/index.ts');
+    expect(updated[1]).toBe(
+      "// claude-lsp-ignore: TS2322,TS6133 Type 'string' is not assignable to type 'number'."
+    );
+    const next = [typeError, unused].map((diag) => ({ ...diag, line: 3 }));
+    expect(filterSuppressed(next, updated).diagnostics).toHaveLength(0);
+  });
+
+  test('should pick the comment syntax from the file language', () => {
+    expect(commentPrefix('main.py')).toBe('#');
+    expect(commentPrefix('init.lua')).toBe('--');
+    expect(commentPrefix('main.go')).toBe('//');
+  });
+
+  test('should use the shebang language for extensionless scripts', () => {
+    const dir = mkdtempSync(join(tmpdir(), 'claude-lsp-suppress-'));
+    try {
+      const script = join(dir, 'migrate');
+      writeFileSync(script, '#!/usr/bin/env python3\nprint(x)\n');
+      expect(commentPrefix(script)).toBe('#');
+    } finally {
+      rmSync(dir, { recursive: true, force: true });
+    }
+  });
+});
@@ -0,0 +1,15 @@
+import { describe, test, expect } from 'bun:test';
+import { findUnusableToolPath } from '../src/cli/utils/tool-paths';
+
+describe('Tool Paths', () => {
+  test('should accept executables and commands on PATH', () => {
+    expect(findUnusableToolPath({ git: 'git', bun: process.execPath })).toBeNull();
+  });
+
+  test('should name the tool whose path is missing', () => {
+    expect(findUnusableToolPath({ go: '/nonexistent/bin/go' })).toBe(
+      'go not found at /nonexistent/bin/go; install go there or fix the tool path'
+    );
+    expect(findUnusableToolPath({ go: 'no-such-go-binary' })).toContain('is not on PATH');
+  });
+});
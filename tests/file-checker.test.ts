@@ -90,19 +90,21 @@ result = add("hello", "world")  # Type error
     }
   });
 
-  test('should return null for JavaScript files (not supported)', async () => {
+  test('should check JavaScript files with tsc checkJs', async () => {
     const testFile = join(TEST_DIR, 'test.js');
     writeFileSync(
       testFile,
       `
-      const x = undefined;
-      x.foo.bar; // Runtime error
+      const x = 42;
+      x.foo.bar(); // Property does not exist on type 'number'
     `
     );
 
     const result = await checkFile(testFile);
-    expect(result).toBeNull();
-    // JavaScript files are not supported in the direct tool invocation mode
+    expect(result).toBeTruthy();
+    expect(result?.tool).toBe('tsc');
+    expect(result?.diagnostics.some((d) => d.severity === 'error')).toBe(true);
+    expect(result?.diagnostics.every((d) => d.code?.startsWith('TS'))).toBe(true);
   });
 
   test('should return null for unsupported files', async () => {
@@ -225,12 +227,14 @@ dist/
     expect(tsResult?.diagnostics.length).toBeGreaterThan(0);
     expect(tsResult?.diagnostics.some((d) => d.severity === 'error')).toBe(true);
 
-    // Check files that should be ignored - should return null or no diagnostics
-    const distResult = await checkFile(join(distDir, 'index.js'));
-    expect(distResult).toBeNull(); // JavaScript files are not supported
+    // Gitignored JavaScript (dist/, *.js) is build output and isn't checked at all
+    writeFileSync(join(distDir, 'index.js'), `const x = 42; x.foo.bar(); // Would be TS2339`);
+    expect(await checkFile(join(distDir, 'index.js'))).toBeNull();
+    expect(await checkFile(join(projectDir, 'build.js'))).toBeNull();
 
-    const buildResult = await checkFile(join(projectDir, 'build.js'));
-    expect(buildResult).toBeNull(); // JavaScript files are not supported
+    // A negation re-includes a file, which is then checked with checkJs
+    writeFileSync(join(projectDir, '.gitignore'), 'dist/\n*.js\n!build.js\n');
+    expect(await checkFile(join(projectDir, 'build.js'))).toBeTruthy();
   });
 
   test('should only report diagnostics for the target file, not imported files', async () => {
@@ -0,0 +1,29 @@
+import { describe, test, expect } from 'bun:test';
+import { mkdtempSync, rmSync, writeFileSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import { detectLanguage, getLanguageForShebang } from '../src/language-extensions';
+
+describe('Language Extensions', () => {
+  test('getLanguageForShebang should find the interpreter behind env', () => {
+    expect(getLanguageForShebang('#!/usr/bin/env python3')).toBe('python');
+    expect(getLanguageForShebang('#!/usr/bin/python3.12 -u')).toBe('python');
+    expect(getLanguageForShebang('#!/usr/bin/env -S deno run --allow-net')).toBe('typescript');
+    expect(getLanguageForShebang('#! /usr/local/bin/node')).toBe('javascript');
+    expect(getLanguageForShebang('#!/bin/bash')).toBeNull();
+    expect(getLanguageForShebang('import os')).toBeNull();
+  });
+
+  test('detectLanguage should prefer the extension and read the shebang otherwise', () => {
+    const dir = mkdtempSync(join(tmpdir(), 'claude-lsp-detect-'));
+    try {
+      writeFileSync(join(dir, 'deploy'), '#!/usr/bin/env node\nconsole.log(1);\n');
+      writeFileSync(join(dir, 'tool.py'), '#!/usr/bin/env node\n');
+      expect(detectLanguage(join(dir, 'deploy'))).toBe('javascript');
+      expect(detectLanguage(join(dir, 'tool.py'))).toBe('python');
+      expect(detectLanguage(join(dir, 'missing'))).toBeNull();
+    } finally {
+      rmSync(dir, { recursive: true, force: true });
+    }
+  });
+});
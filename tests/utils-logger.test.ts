@@ -0,0 +1,44 @@
+import { describe, test, expect, afterEach } from 'bun:test';
+import { existsSync, readFileSync, rmSync } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
+import { configureLogger, logger } from '../src/utils/logger';
+
+describe('Logger', () => {
+  const logFile = join(tmpdir(), `claude-lsp-logger-${Date.now()}.log`);
+
+  afterEach(() => {
+    configureLogger({});
+    rmSync(logFile, { force: true });
+  });
+
+  test('should write nothing until configured', () => {
+    configureLogger({ file: undefined });
+    logger.error('ignored');
+    expect(existsSync(logFile)).toBe(false);
+  });
+
+  test('should write one JSON object per line with fields', () => {
+    configureLogger({ file: logFile, format: 'json' });
+    logger.info('checked file', { phase: 'check', file: 'src/a.ts', diagnostic_count: 2 });
+
+    const entry = JSON.parse(readFileSync(logFile, 'utf8').trim());
+    expect(entry).toMatchObject({
+      level: 'info',
+      msg: 'checked file',
+      phase: 'check',
+      file: 'src/a.ts',
+      diagnostic_count: 2,
+    });
+  });
+
+  test('should drop entries below the configured level', () => {
+    configureLogger({ file: logFile, level: 'warn' });
+    logger.info('too chatty');
+    logger.warn('checker timed out', { command: 'go build main.go' });
+
+    const lines = readFileSync(logFile, 'utf8').trim().split('\n');
+    expect(lines).toHaveLength(1);
+    expect(lines[0]).toContain('WARN checker timed out command="go build main.go"');
+  });
+});
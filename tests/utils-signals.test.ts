@@ -0,0 +1,39 @@
+import { describe, test, expect } from 'bun:test';
+import { join } from 'path';
+
+// Stopping is one-way for a process, so each case runs in its own bun process
+function runScript(script: string): { exitCode: number | null; stdout: string } {
+  const proc = Bun.spawnSync([process.execPath, '-e', script], { timeout: 10000 });
+  return { exitCode: proc.exitCode, stdout: proc.stdout.toString().trim() };
+}
+
+const srcDir = join(import.meta.dir, '..', 'src', 'utils');
+
+describe('Signal Handling', () => {
+  test('should stop running commands without reporting their output', () => {
+    const { stdout } = runScript(`
+      import { execCommand, stopRunningProcesses } from '${join(srcDir, 'common')}';
+      let returned = false;
+      void execCommand(['sleep', '30']).then(() => { returned = true; });
+      await Bun.sleep(100);
+      const startedAt = Date.now();
+      await stopRunningProcesses(2000);
+      await Bun.sleep(50);
+      console.log(JSON.stringify({ returned, fast: Date.now() - startedAt < 2000 }));
+    `);
+    expect(JSON.parse(stdout)).toEqual({ returned: false, fast: true });
+  });
+
+  test('should exit with 130 on SIGINT after stopping commands', () => {
+    const { exitCode } = runScript(`
+      import { execCommand } from '${join(srcDir, 'common')}';
+      import { installSignalHandlers } from '${join(srcDir, 'signals')}';
+      installSignalHandlers();
+      void execCommand(['sleep', '30']);
+      await Bun.sleep(100);
+      process.kill(process.pid, 'SIGINT');
+      await Bun.sleep(5000);
+    `);
+    expect(exitCode).toBe(130);
+  });
+});